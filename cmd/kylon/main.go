@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
@@ -9,7 +10,11 @@ import (
 )
 
 func main() {
-	p := tea.NewProgram(app.InitialModel())
+	apiURL := flag.String("api-url", "http://localhost:8080", "Base URL of the Kylon backend API")
+	token := flag.String("token", "", "Bearer token to authenticate against the Kylon backend API")
+	flag.Parse()
+
+	p := tea.NewProgram(app.InitialModel(*apiURL, *token))
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Uh oh, an error occurred: %v\n", err)
 		os.Exit(1)
@@ -0,0 +1,171 @@
+/**
+ * @File: client.go
+ * @Title: Kylon API Client
+ * @Description: A small HTTP client the TUI uses to talk to the Kylon
+ * @Description: backend's /api/v1 endpoints (and its root /healthz), wrapping
+ * @Description: the server's standard APIResponse envelope.
+ */
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// defaultTimeout bounds any single request made by Client.
+const defaultTimeout = 10 * time.Second
+
+// Client is a minimal client for the Kylon backend API.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// New creates a Client targeting baseURL (e.g. "http://localhost:8080").
+// token, if non-empty, is sent as a Bearer token on every request.
+func New(baseURL, token string) *Client {
+	return &Client{
+		baseURL:    baseURL,
+		token:      token,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+// Cluster mirrors the server's types.ClusterSummary DTO.
+type Cluster struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// Pod mirrors the server's types.PodSummary DTO.
+type Pod struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Status    string `json:"status"`
+	Ready     string `json:"ready"`
+	Node      string `json:"node"`
+}
+
+// apiResponse mirrors utils.APIResponse; Data is left as raw JSON so callers
+// can unmarshal it into the type they expect.
+type apiResponse struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+	Error   *apiErrorDetail `json:"error"`
+}
+
+// apiErrorDetail mirrors utils.APIErrorDetail.
+type apiErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ListClusters calls GET /api/v1/clusters.
+func (c *Client) ListClusters(ctx context.Context) ([]Cluster, error) {
+	var clusters []Cluster
+	if err := c.do(ctx, http.MethodGet, "/api/v1/clusters", "", nil, &clusters); err != nil {
+		return nil, err
+	}
+	return clusters, nil
+}
+
+// ListPods calls GET /api/v1/clusters/:id/pods for clusterID.
+func (c *Client) ListPods(ctx context.Context, clusterID string) ([]Pod, error) {
+	var pods []Pod
+	path := fmt.Sprintf("/api/v1/clusters/%s/pods", clusterID)
+	if err := c.do(ctx, http.MethodGet, path, "", nil, &pods); err != nil {
+		return nil, err
+	}
+	return pods, nil
+}
+
+// RegisterCluster calls POST /api/v1/clusters, uploading the kubeconfig file
+// at kubeconfigPath as the "kubeconfig_file" multipart field.
+func (c *Client) RegisterCluster(ctx context.Context, name, kubeconfigPath string) (*Cluster, error) {
+	file, err := os.Open(kubeconfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open kubeconfig file %q: %w", kubeconfigPath, err)
+	}
+	defer file.Close()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("name", name); err != nil {
+		return nil, err
+	}
+	part, err := writer.CreateFormFile("kubeconfig_file", filepath.Base(kubeconfigPath))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var cluster Cluster
+	if err := c.do(ctx, http.MethodPost, "/api/v1/clusters", writer.FormDataContentType(), &body, &cluster); err != nil {
+		return nil, err
+	}
+	return &cluster, nil
+}
+
+// Healthz calls GET /healthz and reports whether the server considers itself
+// healthy (HTTP 200 with success=true).
+func (c *Client) Healthz(ctx context.Context) bool {
+	err := c.do(ctx, http.MethodGet, "/healthz", "", nil, nil)
+	return err == nil
+}
+
+// do issues an HTTP request against the Kylon API and unmarshals a
+// successful response's Data field into out (skipped if out is nil).
+func (c *Client) do(ctx context.Context, method, path, contentType string, body io.Reader, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var apiResp apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", path, err)
+	}
+
+	if !apiResp.Success {
+		if apiResp.Error != nil {
+			return errors.New(apiResp.Error.Message)
+		}
+		return fmt.Errorf("request to %s failed with status %d", path, resp.StatusCode)
+	}
+
+	if out == nil || len(apiResp.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(apiResp.Data, out)
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/thesyscoder/kylon/internal/app/client"
+)
+
+// apiCallTimeout bounds any single API call issued by a tea.Cmd below.
+const apiCallTimeout = 8 * time.Second
+
+// healthCheckInterval is how often the status bar re-probes /healthz.
+const healthCheckInterval = 15 * time.Second
+
+// fetchClustersCmd requests the cluster list from the Kylon API.
+func fetchClustersCmd(c *client.Client) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout)
+		defer cancel()
+
+		clusters, err := c.ListClusters(ctx)
+		if err != nil {
+			return errMsg{err}
+		}
+		return clustersLoadedMsg{clusters: clusters}
+	}
+}
+
+// fetchPodsCmd requests the pod list for clusterID from the Kylon API.
+func fetchPodsCmd(c *client.Client, clusterID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout)
+		defer cancel()
+
+		pods, err := c.ListPods(ctx, clusterID)
+		if err != nil {
+			return errMsg{err}
+		}
+		return podsLoadedMsg{clusterID: clusterID, pods: pods}
+	}
+}
+
+// registerClusterCmd submits the "register new cluster" form.
+func registerClusterCmd(c *client.Client, name, kubeconfigPath string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout)
+		defer cancel()
+
+		cluster, err := c.RegisterCluster(ctx, name, kubeconfigPath)
+		if err != nil {
+			return errMsg{err}
+		}
+		return clusterRegisteredMsg{cluster: *cluster}
+	}
+}
+
+// checkHealthCmd probes the Kylon API's /healthz endpoint for the status bar.
+func checkHealthCmd(c *client.Client) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout)
+		defer cancel()
+
+		return healthCheckedMsg{healthy: c.Healthz(ctx)}
+	}
+}
+
+// scheduleHealthTick arranges for the next healthTickMsg after healthCheckInterval.
+func scheduleHealthTick() tea.Cmd {
+	return tea.Tick(healthCheckInterval, func(time.Time) tea.Msg {
+		return healthTickMsg{}
+	})
+}
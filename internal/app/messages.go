@@ -0,0 +1,38 @@
+package app
+
+import "github.com/thesyscoder/kylon/internal/app/client"
+
+// clustersLoadedMsg carries the result of an asynchronous GET /clusters call.
+type clustersLoadedMsg struct {
+	clusters []client.Cluster
+}
+
+// podsLoadedMsg carries the result of an asynchronous
+// GET /clusters/:id/pods call for the currently selected cluster.
+type podsLoadedMsg struct {
+	clusterID string
+	pods      []client.Pod
+}
+
+// clusterRegisteredMsg reports that the "register new cluster" form was
+// submitted successfully.
+type clusterRegisteredMsg struct {
+	cluster client.Cluster
+}
+
+// healthCheckedMsg carries the result of a GET /healthz probe used to drive
+// the status bar's API health indicator.
+type healthCheckedMsg struct {
+	healthy bool
+}
+
+// healthTickMsg fires periodically to trigger the next /healthz probe.
+type healthTickMsg struct{}
+
+// errMsg wraps any error surfaced by a command so Update can render it in the
+// status bar without crashing the program.
+type errMsg struct {
+	err error
+}
+
+func (e errMsg) Error() string { return e.err.Error() }
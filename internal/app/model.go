@@ -1,17 +1,122 @@
 package app
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/thesyscoder/kylon/internal/app/client"
+)
 
-// Model is the main application state.
-type Model struct{}
+// focus identifies which pane/view currently has keyboard focus.
+type focus int
 
-// InitialModel returns a new, initialized Model.
-func InitialModel() Model {
-	return Model{}
+const (
+	focusClusters focus = iota
+	focusPods
+	focusRegisterForm
+)
+
+// registerFormField indexes the fields of the "register new cluster" form.
+type registerFormField int
+
+const (
+	registerFieldName registerFormField = iota
+	registerFieldKubeconfigPath
+	registerFieldCount
+)
+
+// Model is the main application state for the Kylon TUI.
+type Model struct {
+	apiClient *client.Client
+
+	focus focus
+
+	clustersTable table.Model
+	clusters      []client.Cluster
+
+	podsTable    table.Model
+	pods         []client.Pod
+	selectedID   string // ID of the cluster the pods pane is showing
+	selectedName string
+
+	detail viewport.Model
+
+	registerInputs     []textinput.Model
+	registerFocusIndex registerFormField
+
+	spinner   spinner.Model
+	loading   bool
+	apiUp     bool
+	statusMsg string
+
+	width  int
+	height int
+}
+
+// InitialModel returns a new, initialized Model that talks to the Kylon API
+// at apiURL, authenticating with token (may be empty).
+func InitialModel(apiURL, token string) Model {
+	apiClient := client.New(apiURL, token)
+
+	clustersTable := newClustersTable()
+	podsTable := newPodsTable()
+
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+
+	nameInput := textinput.New()
+	nameInput.Placeholder = "cluster name"
+	nameInput.Focus()
+
+	pathInput := textinput.New()
+	pathInput.Placeholder = "/path/to/kubeconfig"
+
+	return Model{
+		apiClient:      apiClient,
+		focus:          focusClusters,
+		clustersTable:  clustersTable,
+		podsTable:      podsTable,
+		detail:         viewport.New(80, 10),
+		registerInputs: []textinput.Model{nameInput, pathInput},
+		spinner:        sp,
+		loading:        true,
+		statusMsg:      "Loading clusters...",
+	}
+}
+
+// newClustersTable builds the table.Model used to list registered clusters.
+func newClustersTable() table.Model {
+	columns := []table.Column{
+		{Title: "Name", Width: 24},
+		{Title: "ID", Width: 36},
+		{Title: "Registered", Width: 20},
+	}
+	t := table.New(table.WithColumns(columns), table.WithFocused(true))
+	return t
+}
+
+// newPodsTable builds the table.Model used to list pods of a selected cluster.
+func newPodsTable() table.Model {
+	columns := []table.Column{
+		{Title: "Namespace", Width: 18},
+		{Title: "Name", Width: 32},
+		{Title: "Ready", Width: 8},
+		{Title: "Status", Width: 12},
+		{Title: "Node", Width: 18},
+	}
+	t := table.New(table.WithColumns(columns))
+	return t
 }
 
-// Init is a Bubble Tea lifecycle method that initializes the application.
-// For now, it doesn't need to do anything.
+// Init is a Bubble Tea lifecycle method that kicks off the initial cluster
+// list fetch, the API health probe, and the loading spinner.
 func (m Model) Init() tea.Cmd {
-	return nil
+	return tea.Batch(
+		fetchClustersCmd(m.apiClient),
+		checkHealthCmd(m.apiClient),
+		scheduleHealthTick(),
+		m.spinner.Tick,
+	)
 }
@@ -1,16 +1,223 @@
 package app
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/thesyscoder/kylon/internal/app/client"
+)
 
 // Update is the core logic for the Bubble Tea program.
-// It receives messages (e.g., keypresses) and updates the model.
+// It receives messages (e.g., keypresses, API responses) and updates the model.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
-			return m, tea.Quit
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.detail.Width = msg.Width
+		m.detail.Height = msg.Height - 6
+		return m, nil
+
+	case clustersLoadedMsg:
+		m.clusters = msg.clusters
+		m.clustersTable.SetRows(clusterRows(m.clusters))
+		m.loading = false
+		m.statusMsg = ""
+		return m, nil
+
+	case podsLoadedMsg:
+		if msg.clusterID == m.selectedID {
+			m.pods = msg.pods
+			m.podsTable.SetRows(podRows(m.pods))
+			m.detail.SetContent(podDetail(m.pods, m.podsTable.Cursor()))
 		}
+		m.loading = false
+		m.statusMsg = ""
+		return m, nil
+
+	case clusterRegisteredMsg:
+		m.focus = focusClusters
+		m.loading = true
+		m.statusMsg = "Cluster registered, refreshing list..."
+		for i := range m.registerInputs {
+			m.registerInputs[i].SetValue("")
+		}
+		return m, fetchClustersCmd(m.apiClient)
+
+	case healthCheckedMsg:
+		m.apiUp = msg.healthy
+		return m, nil
+
+	case healthTickMsg:
+		return m, tea.Batch(checkHealthCmd(m.apiClient), scheduleHealthTick())
+
+	case errMsg:
+		m.loading = false
+		m.statusMsg = "Error: " + msg.Error()
+		return m, nil
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
 	}
+
 	return m, nil
 }
+
+// handleKey dispatches a key press according to which pane currently has
+// focus.
+func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	switch m.focus {
+	case focusRegisterForm:
+		return m.handleRegisterFormKey(msg)
+	case focusPods:
+		return m.handlePodsKey(msg)
+	default:
+		return m.handleClustersKey(msg)
+	}
+}
+
+func (m Model) handleClustersKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "n":
+		m.focus = focusRegisterForm
+		m.focusRegisterField(registerFieldName)
+		return m, nil
+	case "r":
+		m.loading = true
+		m.statusMsg = "Refreshing clusters..."
+		return m, fetchClustersCmd(m.apiClient)
+	case "enter":
+		if selected, ok := m.selectedCluster(); ok {
+			m.focus = focusPods
+			m.selectedID = selected.ID
+			m.selectedName = selected.Name
+			m.loading = true
+			m.statusMsg = "Loading pods for " + selected.Name + "..."
+			return m, fetchPodsCmd(m.apiClient, selected.ID)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.clustersTable, cmd = m.clustersTable.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handlePodsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "backspace":
+		m.focus = focusClusters
+		return m, nil
+	case "r":
+		m.loading = true
+		m.statusMsg = "Refreshing pods for " + m.selectedName + "..."
+		return m, fetchPodsCmd(m.apiClient, m.selectedID)
+	}
+
+	var cmd tea.Cmd
+	m.podsTable, cmd = m.podsTable.Update(msg)
+	m.detail.SetContent(podDetail(m.pods, m.podsTable.Cursor()))
+	return m, cmd
+}
+
+func (m Model) handleRegisterFormKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.focus = focusClusters
+		return m, nil
+	case "tab", "down":
+		m.focusRegisterField((m.registerFocusIndex + 1) % registerFieldCount)
+		return m, nil
+	case "shift+tab", "up":
+		m.focusRegisterField((m.registerFocusIndex - 1 + registerFieldCount) % registerFieldCount)
+		return m, nil
+	case "enter":
+		if m.registerFocusIndex != registerFieldKubeconfigPath {
+			m.focusRegisterField(registerFieldKubeconfigPath)
+			return m, nil
+		}
+		name := m.registerInputs[registerFieldName].Value()
+		path := m.registerInputs[registerFieldKubeconfigPath].Value()
+		if name == "" || path == "" {
+			m.statusMsg = "Both name and kubeconfig path are required."
+			return m, nil
+		}
+		m.loading = true
+		m.statusMsg = "Registering cluster..."
+		return m, registerClusterCmd(m.apiClient, name, path)
+	}
+
+	var cmd tea.Cmd
+	m.registerInputs[m.registerFocusIndex], cmd = m.registerInputs[m.registerFocusIndex].Update(msg)
+	return m, cmd
+}
+
+// focusRegisterField moves focus to field within the register form's inputs.
+func (m *Model) focusRegisterField(field registerFormField) {
+	m.registerFocusIndex = field
+	for i := range m.registerInputs {
+		if registerFormField(i) == field {
+			m.registerInputs[i].Focus()
+		} else {
+			m.registerInputs[i].Blur()
+		}
+	}
+}
+
+// selectedCluster returns the cluster currently highlighted in the clusters
+// table, if any.
+func (m Model) selectedCluster() (client.Cluster, bool) {
+	row := m.clustersTable.SelectedRow()
+	if row == nil {
+		return client.Cluster{}, false
+	}
+	id := row[1] // ID column, see newClustersTable
+	for _, c := range m.clusters {
+		if c.ID == id {
+			return c, true
+		}
+	}
+	return client.Cluster{}, false
+}
+
+// clusterRows converts clusters into table.Row values for the clusters table.
+func clusterRows(clusters []client.Cluster) []table.Row {
+	rows := make([]table.Row, 0, len(clusters))
+	for _, c := range clusters {
+		rows = append(rows, table.Row{c.Name, c.ID, c.CreatedAt})
+	}
+	return rows
+}
+
+// podRows converts pods into table.Row values for the pods table.
+func podRows(pods []client.Pod) []table.Row {
+	rows := make([]table.Row, 0, len(pods))
+	for _, p := range pods {
+		rows = append(rows, table.Row{p.Namespace, p.Name, p.Ready, p.Status, p.Node})
+	}
+	return rows
+}
+
+// podDetail renders the detail pane content for the pod at cursor, if any.
+func podDetail(pods []client.Pod, cursor int) string {
+	if cursor < 0 || cursor >= len(pods) {
+		return "No pod selected."
+	}
+	p := pods[cursor]
+	return fmt.Sprintf(
+		"Name:      %s\nNamespace: %s\nStatus:    %s\nReady:     %s\nNode:      %s",
+		p.Name, p.Namespace, p.Status, p.Ready, p.Node,
+	)
+}
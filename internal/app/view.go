@@ -0,0 +1,89 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	titleStyle  = lipgloss.NewStyle().Bold(true).Padding(0, 1)
+	statusStyle = lipgloss.NewStyle().Faint(true).Padding(0, 1)
+	healthUp    = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))  // green
+	healthDown  = lipgloss.NewStyle().Foreground(lipgloss.Color("196")) // red
+	helpStyle   = lipgloss.NewStyle().Faint(true)
+)
+
+// View renders the current Model as a string, per the tea.Model interface.
+func (m Model) View() string {
+	var b strings.Builder
+
+	b.WriteString(m.renderHeader())
+	b.WriteString("\n\n")
+
+	switch m.focus {
+	case focusRegisterForm:
+		b.WriteString(m.renderRegisterForm())
+	case focusPods:
+		b.WriteString(m.renderPods())
+	default:
+		b.WriteString(m.renderClusters())
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(m.renderStatusBar())
+
+	return b.String()
+}
+
+func (m Model) renderHeader() string {
+	return titleStyle.Render("Kylon — Multi-Cluster Dashboard")
+}
+
+func (m Model) renderClusters() string {
+	var b strings.Builder
+	b.WriteString(m.clustersTable.View())
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("↑/↓ navigate · enter drill in · n register cluster · r refresh · q quit"))
+	return b.String()
+}
+
+func (m Model) renderPods() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Pods — %s", m.selectedName)))
+	b.WriteString("\n")
+	b.WriteString(m.podsTable.View())
+	b.WriteString("\n\n")
+	b.WriteString(titleStyle.Render("Detail"))
+	b.WriteString("\n")
+	b.WriteString(m.detail.View())
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("↑/↓ navigate · esc back · r refresh · q quit"))
+	return b.String()
+}
+
+func (m Model) renderRegisterForm() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Register New Cluster"))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf("  Name:       %s\n", m.registerInputs[registerFieldName].View()))
+	b.WriteString(fmt.Sprintf("  Kubeconfig: %s\n", m.registerInputs[registerFieldKubeconfigPath].View()))
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("tab/shift+tab switch field · enter submit (on kubeconfig field) · esc cancel"))
+	return b.String()
+}
+
+func (m Model) renderStatusBar() string {
+	health := healthDown.Render("API: down")
+	if m.apiUp {
+		health = healthUp.Render("API: up")
+	}
+
+	status := m.statusMsg
+	if m.loading {
+		status = fmt.Sprintf("%s %s", m.spinner.View(), status)
+	}
+
+	return statusStyle.Render(fmt.Sprintf("%s  |  %s", health, status))
+}
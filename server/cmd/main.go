@@ -3,13 +3,15 @@
  * @Title: Kylon Backend Server Entry Point
  * @Description: This is the main entry point for the Kylon backend application.
  * @Description: It handles application initialization, including configuration loading,
- * @Description: logger setup, and Kubernetes client initialization.
+ * @Description: logger setup, kubeconfig KeyService initialization, and Kubernetes
+ * @Description: client initialization.
  * @Author: thesyscoder (github.com/thesyscoder)
  */
 
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -17,7 +19,9 @@ import (
 	"github.com/thesyscoder/kylon/internal/infrastructure/config"
 	"github.com/thesyscoder/kylon/internal/infrastructure/database"
 	"github.com/thesyscoder/kylon/internal/infrastructure/kubernetes"
+	"github.com/thesyscoder/kylon/pkg/configz"
 	"github.com/thesyscoder/kylon/pkg/logger"
+	"github.com/thesyscoder/kylon/pkg/secrets"
 	"gorm.io/gorm"
 	k8sClient "k8s.io/client-go/kubernetes" // Added k8s.io/client-go/kubernetes alias
 )
@@ -32,6 +36,9 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Publish the effective (redacted) configuration under GET /configz.
+	configz.New("kylon").Set(cfg)
+
 	// --- Step 2: Initialize Logger based on Config ---
 	// The custom logger's level is set based on the loaded configuration.
 	// Ensure logger.SetLogger exists and correctly configures logrus.
@@ -52,7 +59,7 @@ func main() {
 
 		// --- Auto-Migrate Database schemas (ONLY if DB connection was successful) ---
 		// If DB connection failed, migrations cannot run.
-		migrateErr := db.AutoMigrate(&models.Cluster{})
+		migrateErr := db.AutoMigrate(&models.Cluster{}, &models.Healthcheck{})
 		if migrateErr != nil {
 			log.WithError(migrateErr).Fatal("Failed to auto-migrate database. Application cannot function without migrations.")
 			// os.Exit(1) is handled by Fatal, which calls os.Exit(1) by default
@@ -79,15 +86,26 @@ func main() {
 		// but it's good practice to check.
 		log.WithError(err).Fatal("Failed to retrieve Kubernetes client instance after successful initialization. Exiting.")
 	}
-	// --- Step 6: Application Server Startup ---
+	// --- Step 6: Initialize the Kubeconfig KeyService ---
+	// Kubeconfigs are envelope-encrypted at rest; the selected backend is
+	// round-trip self-tested here so a misconfiguration fails startup instead
+	// of surfacing on the first cluster registration.
+	log.Info("Initializing kubeconfig KeyService...")
+	keyService, err := secrets.NewKeyService(context.Background(), cfg)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to initialize kubeconfig KeyService. Exiting.")
+	}
+	log.Info("KeyService initialized successfully.")
+
+	// --- Step 7: Application Server Startup ---
 	log.Infof("Starting Kylon backend in %s mode on port %s", cfg.App.Env, cfg.App.Port) // Use App.Env for clarity
 
 	// Instantiate our custom server, injecting dependencies.
 	// Pass the 'db' variable, which will be nil if the connection failed,
 	// or a valid *gorm.DB if successful.
-	appServer := NewServer(cfg, db, kubeClient, log) // Pass 'log' (logrus) instance
+	appServer := NewServer(cfg, db, kubeClient, keyService, log) // Pass 'log' (logrus) instance
 
-	// --- Step 7: Start Server ---
+	// --- Step 8: Start Server ---
 	// Start the HTTP server and block until a shutdown signal is received.
 	// The server will now start even if the database connection failed,
 	// allowing the health endpoint to respond appropriately.
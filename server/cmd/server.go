@@ -14,33 +14,64 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	"github.com/thesyscoder/kylon/internal/app/routes"
+	"github.com/thesyscoder/kylon/internal/app/scheduler"
+	"github.com/thesyscoder/kylon/internal/app/services"
 	"github.com/thesyscoder/kylon/internal/infrastructure/config"
+	kube "github.com/thesyscoder/kylon/internal/infrastructure/kubernetes"
+	"github.com/thesyscoder/kylon/internal/infrastructure/repositories"
+	"github.com/thesyscoder/kylon/pkg/secrets"
 	"gorm.io/gorm"
 	"k8s.io/client-go/kubernetes"
 )
 
 // Server encapsulates the Gin engine and application configuration.
 type Server struct {
-	Gin *gin.Engine
-	Cfg *config.Config
-	DB  *gorm.DB
-	Log *logrus.Logger // Renamed from 'log' to 'Log' to avoid shadowing
+	Gin            *gin.Engine
+	Cfg            *config.Config
+	DB             *gorm.DB
+	ClientCache    *kube.ClientCache        // Per-cluster Kubernetes client cache, keyed by cluster UUID.
+	ClusterManager *services.ClusterManager // Per-cluster live status cache, kept fresh by Scheduler.
+	Scheduler      *scheduler.Scheduler     // Background reconciler for registered clusters' status.
+	Log            *logrus.Logger           // Renamed from 'log' to 'Log' to avoid shadowing
 }
 
-// NewServer creates and returns a new Server instance.
-func NewServer(cfg *config.Config, db *gorm.DB, kubeClient *kubernetes.Clientset, appLogger *logrus.Logger) *Server { // Renamed param to appLogger
+// NewServer creates and returns a new Server instance. keyService must already
+// be initialized (and self-tested) by the caller; see secrets.NewKeyService.
+func NewServer(cfg *config.Config, db *gorm.DB, kubeClient *kubernetes.Clientset, keyService secrets.KeyService, appLogger *logrus.Logger) *Server { // Renamed param to appLogger
 	// Set Gin mode (ReleaseMode is good for production)
 	gin.SetMode(gin.ReleaseMode)
 
+	// The client cache resolves a Clientset for any registered cluster on
+	// demand from its decrypted kubeconfig; it shares the same
+	// ClusterRepository the cluster routes use so lookups stay consistent.
+	clusterRepo := repositories.NewClusterRepository(db, appLogger)
+	clientCache := kube.NewClientCache(clusterRepo, keyService, appLogger)
+	clusterManager := services.NewClusterManager()
+	clusterScheduler := scheduler.NewScheduler(clusterRepo, clientCache, clusterManager, cfg, appLogger)
+
+	if cfg.Cluster.AutoRegisterInCluster {
+		clusterService := services.NewClusterService(clusterRepo, clusterManager, appLogger)
+		if err := services.AutoRegisterInCluster(context.Background(), clusterRepo, clusterService, keyService, clientCache, appLogger); err != nil {
+			// This is only expected to fail outside a Kubernetes pod, where
+			// rest.InClusterConfig() has no service account token/CA to read;
+			// any other failure here (e.g. a database or encryption error) is
+			// a real startup problem worth investigating, not a harmless no-op.
+			appLogger.WithError(err).Warn("In-cluster auto-registration failed; this is expected only when Kylon is not running inside a Kubernetes pod.")
+		}
+	}
+
 	// Initialize routes with all necessary dependencies
 	// Pass kubeClient to InitializeRoutes
-	router := routes.InitializeRoutes(cfg, db, appLogger, kubeClient)
+	router := routes.InitializeRoutes(cfg, db, appLogger, kubeClient, clientCache, clusterManager, keyService)
 
 	return &Server{
-		Gin: router,
-		Cfg: cfg,
-		DB:  db,
-		Log: appLogger, // Use the passed appLogger
+		Gin:            router,
+		Cfg:            cfg,
+		DB:             db,
+		ClientCache:    clientCache,
+		ClusterManager: clusterManager,
+		Scheduler:      clusterScheduler,
+		Log:            appLogger, // Use the passed appLogger
 	}
 }
 
@@ -54,6 +85,14 @@ func (s *Server) Start() {
 		Handler: s.Gin,
 	}
 
+	// Run the cluster reconciliation scheduler in the background for as long
+	// as the server is up; it stops when schedulerCancel is called during
+	// shutdown, and itself respects cfg.App.ShutdownTimeout when winding down
+	// an in-flight pass.
+	schedulerCtx, schedulerCancel := context.WithCancel(context.Background())
+	defer schedulerCancel()
+	go s.Scheduler.Start(schedulerCtx)
+
 	// Run server in a goroutine
 	go func() {
 		s.Log.Infof("Starting HTTP server at %s", addr) // Use s.Log for structured logging
@@ -72,8 +111,12 @@ func (s *Server) Start() {
 	s.Log.Info("Shutdown signal received, shutting down gracefully...")
 
 	// Create a context with a timeout for graceful shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second) // 5 seconds timeout
-	defer cancel()                                                          // Ensure cancel is called to release context resources
+	shutdownTimeout := s.Cfg.App.ShutdownTimeout
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = 5 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel() // Ensure cancel is called to release context resources
 
 	// Attempt to gracefully shut down the server
 	if err := server.Shutdown(ctx); err != nil {
@@ -0,0 +1,117 @@
+/**
+ * @File: bundle.handler.go
+ * @Title: Resource Bundle Status Handler
+ * @Description: Surfaces a tracked app's ResourceBundleState status -- as
+ * @Description: last observed by the tracker's per-cluster reconciler -- over
+ * @Description: HTTP.
+ * @Author: thesyscoder (github.com/thesyscoder)
+ */
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/thesyscoder/kylon/internal/app/tracker"
+	"github.com/thesyscoder/kylon/internal/app/utils"
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// defaultBundleNamespace is used when a bundle status request doesn't
+// specify a "namespace" query parameter, matching kubectl's default
+// namespace convention.
+const defaultBundleNamespace = "default"
+
+// BundleHandler handles HTTP requests for tracked resource bundle status.
+type BundleHandler struct {
+	tracker *tracker.Tracker
+	log     *logrus.Logger
+}
+
+// NewBundleHandler creates a new BundleHandler.
+func NewBundleHandler(tracker *tracker.Tracker, log *logrus.Logger) *BundleHandler {
+	if tracker == nil {
+		log.Fatal("Tracker is nil when creating BundleHandler. Critical setup error.")
+	}
+	return &BundleHandler{tracker: tracker, log: log}
+}
+
+// GetBundleStatus handles GET /api/v1/clusters/:id/bundles/:app/status,
+// returning the app's last-reconciled BundleStatus on the given cluster. An
+// optional "namespace" query parameter selects the bundle's namespace
+// (default "default").
+func (h *BundleHandler) GetBundleStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	clusterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, customerrors.NewCustomError(customerrors.ErrCodeInvalidInput, "Cluster ID must be a valid UUID.", err, http.StatusBadRequest, nil))
+		return
+	}
+
+	appName := c.Param("app")
+	namespace := c.DefaultQuery("namespace", defaultBundleNamespace)
+
+	status, err := h.tracker.Status(ctx, clusterID, appName, namespace)
+	if err != nil {
+		h.log.WithContext(ctx).WithField("cluster_id", clusterID).WithField("app", appName).WithError(err).Warn("Failed to retrieve bundle status.")
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Bundle status retrieved successfully.", status)
+}
+
+// ApplyBundleRequest is the request body for ApplyBundle: a raw list of
+// Kubernetes manifests (each a decoded JSON object, e.g. a Deployment,
+// Service or Pod) to apply as one app's tracked bundle.
+type ApplyBundleRequest struct {
+	Namespace string                   `json:"namespace,omitempty"`
+	Manifests []map[string]interface{} `json:"manifests" binding:"required"`
+}
+
+// ApplyBundle handles POST /api/v1/clusters/:id/bundles/:app/apply, the only
+// path through which kylon applies resources to a registered cluster on an
+// app's behalf. Every manifest is stamped with the app's instance label and
+// applied idempotently (created if absent, updated in place otherwise), then
+// recorded as a ResourceBundleState CR via tracker.ApplyBundle so
+// GetBundleStatus has something to report on.
+func (h *BundleHandler) ApplyBundle(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	clusterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, customerrors.NewCustomError(customerrors.ErrCodeInvalidInput, "Cluster ID must be a valid UUID.", err, http.StatusBadRequest, nil))
+		return
+	}
+	appName := c.Param("app")
+
+	var req ApplyBundleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, customerrors.NewCustomError(customerrors.ErrCodeInvalidInput, "At least one manifest is required.", err, http.StatusBadRequest, nil))
+		return
+	}
+
+	namespace := req.Namespace
+	if namespace == "" {
+		namespace = defaultBundleNamespace
+	}
+
+	manifests := make([]*unstructured.Unstructured, 0, len(req.Manifests))
+	for _, manifest := range req.Manifests {
+		manifests = append(manifests, &unstructured.Unstructured{Object: manifest})
+	}
+
+	refs, err := h.tracker.ApplyBundle(ctx, clusterID, appName, namespace, manifests)
+	if err != nil {
+		h.log.WithContext(ctx).WithField("cluster_id", clusterID).WithField("app", appName).WithError(err).Warn("Failed to apply resource bundle.")
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Resource bundle applied successfully.", refs)
+}
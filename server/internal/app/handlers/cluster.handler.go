@@ -4,32 +4,51 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
-	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/thesyscoder/kylon/internal/app/services"
 	"github.com/thesyscoder/kylon/internal/app/utils"
+	"github.com/thesyscoder/kylon/internal/domain/types"
+	kube "github.com/thesyscoder/kylon/internal/infrastructure/kubernetes"
 	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+	"github.com/thesyscoder/kylon/pkg/secrets"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // ClusterHandler handles HTTP requests related to cluster management.
 type ClusterHandler struct {
 	clusterService services.ClusterService
-	log            *logrus.Logger
+	// clientCache resolves a Kubernetes Clientset for any registered cluster by
+	// ID, so per-cluster endpoints (pods, deployments, logs, etc.) never need
+	// to build their own client from scratch.
+	clientCache *kube.ClientCache
+	// keyService envelope-encrypts uploaded kubeconfigs before they are ever
+	// persisted; see pkg/secrets.
+	keyService secrets.KeyService
+	// kubeconfigSaveDir is where kubeconfig-blob registrations are additionally
+	// persisted (as their ciphertext, atomically) via kube.PersistKubeconfigBlob.
+	// Empty disables this; see KubernetesConfig.KubeconfigSaveDir.
+	kubeconfigSaveDir string
+	log               *logrus.Logger
 }
 
 // NewClusterHandler creates a new ClusterHandler.
-func NewClusterHandler(clusterService services.ClusterService, log *logrus.Logger) *ClusterHandler {
+func NewClusterHandler(clusterService services.ClusterService, clientCache *kube.ClientCache, keyService secrets.KeyService, kubeconfigSaveDir string, log *logrus.Logger) *ClusterHandler {
 	if clusterService == nil {
 		log.Fatal("ClusterService is nil when creating ClusterHandler. Critical setup error.")
 	}
+	if keyService == nil {
+		log.Fatal("KeyService is nil when creating ClusterHandler. Critical setup error.")
+	}
 	return &ClusterHandler{
-		clusterService: clusterService,
-		log:            log,
+		clusterService:    clusterService,
+		clientCache:       clientCache,
+		keyService:        keyService,
+		kubeconfigSaveDir: kubeconfigSaveDir,
+		log:               log,
 	}
 }
 
@@ -96,13 +115,14 @@ func (h *ClusterHandler) RegisterCluster(c *gin.Context) {
 	}
 	defer src.Close()
 
-	// Prepare directory for kubeconfigs
-	saveDir := "./data/kubeconfigs"
-	if err := os.MkdirAll(saveDir, 0700); err != nil {
+	// Read the uploaded kubeconfig straight into memory and encrypt it -- it
+	// is never written to disk in plaintext.
+	plaintext, err := io.ReadAll(src)
+	if err != nil {
 		utils.ErrorResponse(
 			c, customerrors.NewCustomError(
 				customerrors.ErrCodeInternal,
-				"Failed to prepare kubeconfig storage.",
+				"Failed to read uploaded kubeconfig file.",
 				err,
 				http.StatusInternalServerError,
 				nil,
@@ -111,55 +131,144 @@ func (h *ClusterHandler) RegisterCluster(c *gin.Context) {
 		return
 	}
 
-	// Build a unique filename: <timestamp>_<cluster_name>.yaml (sanitized name for path safety)
-	safeName := strings.ReplaceAll(strings.TrimSpace(name), " ", "_")
-	timestamp := time.Now().Unix()
-	filePath := filepath.Join(saveDir,
-		filepath.Base(
-			fmt.Sprintf("%d_%s.yaml", timestamp, safeName),
-		),
-	)
+	// Validate the kubeconfig and probe the cluster it points at before we
+	// ever encrypt or persist anything -- bad or unreachable kubeconfigs are
+	// rejected here with a specific, actionable error, unless the caller set
+	// force=true, in which case the cluster is still registered but in a
+	// degraded/unreachable state (mirroring how Pulumi's provider falls back
+	// to a degraded default for an unreachable kubeconfig).
+	probe, probeErr := services.ProbeKubeconfig(ctx, plaintext)
+	force := c.PostForm("force") == "true"
+	if probeErr != nil {
+		h.log.WithContext(ctx).WithError(probeErr).Warn("Uploaded kubeconfig failed validation or reachability probe.")
+		if !force {
+			utils.ErrorResponse(c, probeErr)
+			return
+		}
+		h.log.WithContext(ctx).Warn("Registering cluster in a degraded state because force=true was set despite the failed probe.")
+	}
+
+	ciphertext, dekID, err := h.keyService.Encrypt(ctx, plaintext)
+	if err != nil {
+		h.log.WithContext(ctx).WithError(err).Error("Failed to encrypt uploaded kubeconfig.")
+		utils.ErrorResponse(c, err)
+		return
+	}
 
-	outFile, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	cluster, err := h.clusterService.RegisterCluster(ctx, name, ciphertext, dekID, probe, probeErr, types.ClusterSourceKubeconfig)
 	if err != nil {
+		h.log.WithContext(ctx).WithError(err).Error("Failed to register cluster after file upload.")
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	if err := kube.PersistKubeconfigBlob(h.kubeconfigSaveDir, cluster.ID, ciphertext); err != nil {
+		// The cluster is already registered and usable; failing to also write
+		// the on-disk copy is logged but not surfaced as a registration error.
+		h.log.WithContext(ctx).WithField("cluster_id", cluster.ID).WithError(err).Error("Failed to persist kubeconfig blob to disk.")
+	}
+
+	h.log.WithContext(ctx).
+		WithField("cluster_id", cluster.ID).
+		Info("Cluster registered successfully via API with encrypted kubeconfig.")
+	utils.SuccessResponse(c, http.StatusCreated, "Cluster registered successfully.", cluster)
+}
+
+// RegisterInClusterCluster handles POST /api/v1/clusters/in-cluster,
+// self-registering the cluster Kylon itself is running on via
+// rest.InClusterConfig() -- no kubeconfig is ever uploaded. The synthesized
+// kubeconfig is probed and encrypted exactly like an uploaded one, but is
+// never written to disk (see kube.PersistKubeconfigBlob).
+func (h *ClusterHandler) RegisterInClusterCluster(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.WithContext(ctx).Info("Received request to self-register the in-cluster Kubernetes cluster.")
+
+	var req struct {
+		Name  string `json:"name" binding:"required"`
+		Force bool   `json:"force,omitempty"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.ErrorResponse(
-			c, customerrors.NewCustomError(
-				customerrors.ErrCodeInternal,
-				"Failed to create kubeconfig file.",
-				err,
-				http.StatusInternalServerError,
-				nil,
-			),
+			c,
+			customerrors.NewCustomError(customerrors.ErrCodeInvalidInput, "Cluster name is required.", err, http.StatusBadRequest, nil),
 		)
 		return
 	}
-	defer outFile.Close()
 
-	if _, err := io.Copy(outFile, src); err != nil {
+	plaintext, err := kube.SynthesizeInClusterKubeconfig()
+	if err != nil {
+		h.log.WithContext(ctx).WithError(err).Warn("Failed to synthesize in-cluster kubeconfig.")
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.registerSynthesizedCluster(c, req.Name, plaintext, types.ClusterSourceInCluster, req.Force)
+}
+
+// RegisterClusterFromToken handles POST /api/v1/clusters/token, registering a
+// cluster from a bare API server URL, CA certificate and bearer token rather
+// than an uploaded kubeconfig.
+func (h *ClusterHandler) RegisterClusterFromToken(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.WithContext(ctx).Info("Received request to register a cluster from a serviceaccount token.")
+
+	var req types.RegisterClusterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.ErrorResponse(
-			c, customerrors.NewCustomError(
-				customerrors.ErrCodeInternal,
-				"Failed to save kubeconfig file.",
-				err,
-				http.StatusInternalServerError,
-				nil,
-			),
+			c,
+			customerrors.NewCustomError(customerrors.ErrCodeInvalidInput, "Cluster name, apiServerURL and bearerToken are required.", err, http.StatusBadRequest, nil),
 		)
 		return
 	}
 
-	// Pass file path only to service (never the YAML content)
-	cluster, err := h.clusterService.RegisterCluster(ctx, name, filePath)
+	plaintext, err := kube.SynthesizeTokenKubeconfig(req.APIServerURL, req.CACert, req.BearerToken, req.Namespace)
 	if err != nil {
-		h.log.WithContext(ctx).WithError(err).Error("Failed to register cluster after file upload.")
+		h.log.WithContext(ctx).WithError(err).Warn("Failed to synthesize kubeconfig from serviceaccount token.")
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.registerSynthesizedCluster(c, req.Name, plaintext, types.ClusterSourceServiceAccountToken, req.Force)
+}
+
+// registerSynthesizedCluster probes, encrypts and registers a kubeconfig that
+// was synthesized server-side (never uploaded by an operator), shared by
+// RegisterInClusterCluster and RegisterClusterFromToken. Synthesized
+// kubeconfigs are never written to disk via kube.PersistKubeconfigBlob --
+// only operator-uploaded blobs (RegisterCluster) are. force registers the
+// cluster in a degraded/unreachable state instead of rejecting it when the
+// probe fails, exactly as RegisterCluster's force=true does.
+func (h *ClusterHandler) registerSynthesizedCluster(c *gin.Context, name string, plaintext []byte, source types.ClusterSource, force bool) {
+	ctx := c.Request.Context()
+
+	probe, probeErr := services.ProbeKubeconfig(ctx, plaintext)
+	if probeErr != nil {
+		h.log.WithContext(ctx).WithError(probeErr).Warn("Synthesized kubeconfig failed validation or reachability probe.")
+		if !force {
+			utils.ErrorResponse(c, probeErr)
+			return
+		}
+		h.log.WithContext(ctx).Warn("Registering cluster in a degraded state because force=true was set despite the failed probe.")
+	}
+
+	ciphertext, dekID, err := h.keyService.Encrypt(ctx, plaintext)
+	if err != nil {
+		h.log.WithContext(ctx).WithError(err).Error("Failed to encrypt synthesized kubeconfig.")
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	cluster, err := h.clusterService.RegisterCluster(ctx, name, ciphertext, dekID, probe, probeErr, source)
+	if err != nil {
+		h.log.WithContext(ctx).WithError(err).Error("Failed to register cluster from synthesized kubeconfig.")
 		utils.ErrorResponse(c, err)
 		return
 	}
 
 	h.log.WithContext(ctx).
 		WithField("cluster_id", cluster.ID).
-		WithField("kubeconfig_path", filePath).
-		Info("Cluster registered successfully via API with uploaded file.")
+		WithField("source", source).
+		Info("Cluster registered successfully from synthesized kubeconfig.")
 	utils.SuccessResponse(c, http.StatusCreated, "Cluster registered successfully.", cluster)
 }
 
@@ -178,3 +287,99 @@ func (h *ClusterHandler) ListClusters(c *gin.Context) {
 		Infof("Successfully retrieved %d clusters for list request.", len(clusters))
 	utils.SuccessResponse(c, http.StatusOK, "Clusters retrieved successfully.", clusters)
 }
+
+// ListPods handles GET /api/v1/clusters/:id/pods, listing pods across all
+// namespaces of the given cluster via the shared ClientCache. An optional
+// "namespace" query parameter restricts the listing to a single namespace.
+func (h *ClusterHandler) ListPods(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	clusterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(
+			c,
+			customerrors.NewCustomError(
+				customerrors.ErrCodeInvalidInput,
+				"Cluster ID must be a valid UUID.",
+				err,
+				http.StatusBadRequest,
+				nil,
+			),
+		)
+		return
+	}
+
+	clientset, err := h.clientCache.ClientFor(ctx, clusterID)
+	if err != nil {
+		h.log.WithContext(ctx).WithError(err).Error("Failed to resolve Kubernetes client for cluster.")
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	namespace := c.Query("namespace") // empty string lists across all namespaces
+	podList, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		h.log.WithContext(ctx).WithError(err).Error("Failed to list pods for cluster.")
+		utils.ErrorResponse(
+			c,
+			customerrors.NewCustomError(
+				customerrors.ErrCodeClusterUnreachable,
+				"Failed to list pods for cluster.",
+				err,
+				http.StatusUnprocessableEntity,
+				nil,
+			),
+		)
+		return
+	}
+
+	pods := make([]types.PodSummary, 0, len(podList.Items))
+	for _, pod := range podList.Items {
+		readyContainers := 0
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Ready {
+				readyContainers++
+			}
+		}
+		pods = append(pods, types.PodSummary{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+			Status:    string(pod.Status.Phase),
+			Ready:     fmt.Sprintf("%d/%d", readyContainers, len(pod.Status.ContainerStatuses)),
+			Node:      pod.Spec.NodeName,
+		})
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Pods retrieved successfully.", pods)
+}
+
+// GetClusterStatus handles GET /api/v1/clusters/:id/status, returning the
+// cluster's most recent reconciliation status as last refreshed by the
+// background scheduler.
+func (h *ClusterHandler) GetClusterStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	clusterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(
+			c,
+			customerrors.NewCustomError(
+				customerrors.ErrCodeInvalidInput,
+				"Cluster ID must be a valid UUID.",
+				err,
+				http.StatusBadRequest,
+				nil,
+			),
+		)
+		return
+	}
+
+	status, err := h.clusterService.GetClusterStatus(ctx, clusterID)
+	if err != nil {
+		h.log.WithContext(ctx).WithError(err).Error("Failed to retrieve cluster status.")
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Cluster status retrieved successfully.", status)
+}
@@ -0,0 +1,43 @@
+/**
+ * @File: configz.handlers.go
+ * @Title: Runtime Log Level Handler
+ * @Description: Lets operators flip the global log level at runtime, without
+ * @Description: a restart, via the /configz surface.
+ * @Author: thesyscoder (github.com/thesyscoder)
+ */
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thesyscoder/kylon/internal/app/utils"
+	"github.com/thesyscoder/kylon/pkg/logger"
+
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+)
+
+// logLevelRequest is the body of POST /configz/log-level.
+type logLevelRequest struct {
+	Level string `json:"level" binding:"required"`
+}
+
+// LogLevelHandler handles POST /configz/log-level, parsing a logrus level
+// name ("debug", "info", "warn", "error", ...) from the request body and
+// applying it to the global logger via logger.SetLogger.
+func LogLevelHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req logLevelRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ErrorResponse(
+				c,
+				customerrors.NewCustomError(customerrors.ErrCodeInvalidInput, "A 'level' field is required (e.g. \"debug\", \"info\", \"warn\", \"error\").", err, http.StatusBadRequest, nil),
+			)
+			return
+		}
+
+		logger.SetLogger(req.Level)
+		utils.SuccessResponse(c, http.StatusOK, "Log level updated.", gin.H{"level": req.Level})
+	}
+}
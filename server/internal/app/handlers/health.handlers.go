@@ -1,140 +1,314 @@
 /**
  * @File: health_handler.go
  * @Title: Health Check Handler
- * @Description: Provides an HTTP handler for performing application health checks,
- * @Description: including detailed status of core dependencies like database and Kubernetes.
+ * @Description: Provides liveness (/healthz) and readiness (/readyz) HTTP handlers,
+ * @Description: both reporting the per-dependency status of the database, every
+ * @Description: registered Kubernetes cluster, and the configured AI endpoint.
  * @Author: thesyscoder (github.com/thesyscoder)
  */
 
 package handlers
 
 import (
-	"context" // For context with timeout
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/thesyscoder/kylon/internal/app/utils"             // For SuccessResponse and ErrorResponse
-	"github.com/thesyscoder/kylon/internal/infrastructure/config" // For application configuration
-	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"  // For structured custom errors
-	"github.com/thesyscoder/kylon/pkg/logger"                     // For centralized logging
-	"gorm.io/gorm"                                                // For database interaction
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"                 // For Kubernetes API options
-	"k8s.io/client-go/kubernetes"                                 // For Kubernetes client interaction
+	"github.com/google/uuid"
+	"github.com/thesyscoder/kylon/internal/app/services"
+	"github.com/thesyscoder/kylon/internal/app/utils"
+	"github.com/thesyscoder/kylon/internal/infrastructure/config"
+	kube "github.com/thesyscoder/kylon/internal/infrastructure/kubernetes"
+	"github.com/thesyscoder/kylon/internal/infrastructure/repositories"
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+	"github.com/thesyscoder/kylon/pkg/logger"
+	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/kubernetes"
 )
 
 // log is the logger instance for this package, providing contextual logging for the health handler.
 var log = logger.GetLogger().WithField("component", "health_handler")
 
+// dependencyProbeTimeout bounds how long any single dependency probe (DB,
+// a registered cluster, the AI endpoint) may take before it is considered
+// unreachable.
+const dependencyProbeTimeout = 3 * time.Second
+
+// defaultClusterUnreachableQuorum is used whenever
+// cfg.App.ClusterUnreachableQuorum is unset or outside (0, 1].
+const defaultClusterUnreachableQuorum = 0.5
+
+// clusterUnreachableQuorum returns the configured quorum fraction, falling
+// back to defaultClusterUnreachableQuorum for an unset or out-of-range value.
+func clusterUnreachableQuorum(cfg *config.Config) float64 {
+	q := cfg.App.ClusterUnreachableQuorum
+	if q <= 0 || q > 1 {
+		return defaultClusterUnreachableQuorum
+	}
+	return q
+}
+
+// DependencyStatus reports the outcome of probing a single dependency.
+type DependencyStatus struct {
+	Status    string `json:"status"` // "UP" or "DOWN"
+	Message   string `json:"message,omitempty"`
+	LatencyMS int64  `json:"latency_ms"`
+}
+
 // HealthCheckResponse defines the structure for a detailed health check API response.
 type HealthCheckResponse struct {
-	Status       string `json:"status"`                // Overall service status (e.g., "UP", "DOWN")
-	Message      string `json:"message"`               // A human-readable message about the service status
-	Application  string `json:"application"`           // Name of the application
-	Version      string `json:"version,omitempty"`     // Application version from config
-	Environment  string `json:"environment,omitempty"` // Application environment from config
-	Timestamp    string `json:"timestamp"`             // Timestamp of when the health check was performed
-	Dependencies struct {
-		Database   string `json:"database"`   // Status of the database dependency
-		Kubernetes string `json:"kubernetes"` // Status of the Kubernetes client dependency
-		// Add more dependencies here as the application grows, e.g., "minio": "UP"
-	} `json:"dependencies"`
+	Status       string                      `json:"status"`                // Overall service status (e.g., "UP", "DOWN")
+	Message      string                      `json:"message"`               // A human-readable message about the service status
+	Application  string                      `json:"application"`           // Name of the application
+	Version      string                      `json:"version,omitempty"`     // Application version from config
+	Environment  string                      `json:"environment,omitempty"` // Application environment from config
+	Timestamp    string                      `json:"timestamp"`             // Timestamp of when the health check was performed
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
 }
 
-// HealthCheckHandler returns a gin.HandlerFunc that performs a comprehensive health check.
-// It assesses the overall application status and the connectivity/status of key dependencies,
-// such as the configured database and Kubernetes cluster.
-// Parameters:
-//   - cfg: Application configuration, used for fetching app details (name, version, environment).
-//   - db: GORM database instance; used to check database connectivity. Can be nil to skip check.
-//   - kubeClient: Kubernetes Clientset instance; used to check Kubernetes API connectivity. Can be nil to skip check.
-func HealthCheckHandler(cfg *config.Config, db *gorm.DB, kubeClient *kubernetes.Clientset) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Initialize the response with default "UP" status.
-		response := HealthCheckResponse{
-			Status:      http.StatusText(http.StatusOK), // Start with "OK" status text
-			Message:     "Service is healthy",
-			Application: cfg.App.Name,
-			Version:     cfg.App.Version,
-			Environment: cfg.App.Env,
-			Timestamp:   time.Now().Format(time.RFC3339),
-			Dependencies: struct {
-				Database   string `json:"database"`
-				Kubernetes string `json:"kubernetes"`
-			}{
-				Database:   "N/A", // Default to "N/A" if dependency is not checked or configured
-				Kubernetes: "N/A",
-			},
-		}
+// probeDependencies probes the database, the control-plane Kubernetes client,
+// every cluster registered via ClusterRepository, and -- when enabled -- the
+// configured AI endpoint. It never panics or aborts early on a single
+// dependency's failure; each is recorded independently so one unreachable
+// cluster degrades only its own entry. Clusters are probed concurrently, each
+// under its own dependencyProbeTimeout, so one slow or unreachable cluster
+// cannot delay the others. It returns the per-dependency report and whether
+// the service as a whole is healthy: the database failing always degrades
+// the result, but an unreachable cluster only does so once the fraction of
+// unreachable clusters reaches clusterUnreachableQuorum(cfg) -- a single bad
+// kubeconfig among many clusters should not take down the whole check.
+func probeDependencies(ctx context.Context, cfg *config.Config, db *gorm.DB, kubeClient *kubernetes.Clientset, clusterRepo repositories.ClusterRepository, clientCache *kube.ClientCache, clusterManager *services.ClusterManager) (map[string]DependencyStatus, bool) {
+	deps := make(map[string]DependencyStatus)
+	var mu sync.Mutex
+	record := func(key string, status DependencyStatus) {
+		mu.Lock()
+		deps[key] = status
+		mu.Unlock()
+	}
 
-		// Initialize overall status to OK. This will be degraded if any dependency fails.
-		overallHTTPStatus := http.StatusOK
-		overallMessage := "Service is healthy"
-
-		// --- Database Health Check ---
-		if db != nil {
-			ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second) // 2-second timeout for DB ping
-			defer cancel()
-
-			sqlDB, err := db.DB() // Get the underlying *sql.DB for pinging
-			if err != nil {
-				log.WithError(err).Error("Health Check: Failed to retrieve underlying DB connection pool.")
-				response.Dependencies.Database = "DOWN - Connection Pool Error"
-				overallHTTPStatus = http.StatusInternalServerError
-				overallMessage = "Service unhealthy: Database connection pool issue"
-			} else if err := sqlDB.PingContext(ctx); err != nil {
-				log.WithError(err).Error("Health Check: Database connectivity check failed.")
-				response.Dependencies.Database = fmt.Sprintf("DOWN - %s", err.Error())
-				overallHTTPStatus = http.StatusInternalServerError
-				overallMessage = "Service unhealthy: Database connectivity failed"
-			} else {
-				response.Dependencies.Database = "UP"
-			}
+	dbHealthy := true
+	if db != nil {
+		status := probeDatabase(ctx, db)
+		record("database", status)
+		dbHealthy = status.Status == "UP"
+	}
+
+	var wg sync.WaitGroup
+
+	if kubeClient != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			record("kubernetes", probeKubeClient(ctx, kubeClient))
+		}()
+	}
+
+	if cfg.AI.Enabled {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			record("ai", probeAIEndpoint(ctx, cfg.AI.ModelEndpoint))
+		}()
+	}
+
+	var unreachableClusters, totalClusters int
+	if clusterRepo != nil && clientCache != nil {
+		clusters, err := clusterRepo.List(ctx)
+		if err != nil {
+			log.WithError(err).Error("Health Check: Failed to list registered clusters.")
+			record("clusters", DependencyStatus{Status: "DOWN", Message: err.Error()})
 		} else {
-			log.Debug("Health Check: Database instance not provided (nil), skipping DB health check.")
+			totalClusters = len(clusters)
+			var clusterWG sync.WaitGroup
+			var counterMu sync.Mutex
+			for _, cluster := range clusters {
+				cluster := cluster
+				clusterWG.Add(1)
+				go func() {
+					defer clusterWG.Done()
+					status := probeCluster(ctx, clientCache, clusterManager, cluster.ID, cluster.Name)
+					record(fmt.Sprintf("cluster:%s", cluster.Name), status)
+					if status.Status != "UP" {
+						counterMu.Lock()
+						unreachableClusters++
+						counterMu.Unlock()
+					}
+				}()
+			}
+			clusterWG.Wait()
 		}
+	}
+
+	wg.Wait()
+
+	quorumBreached := totalClusters > 0 && float64(unreachableClusters)/float64(totalClusters) >= clusterUnreachableQuorum(cfg)
+	return deps, dbHealthy && !quorumBreached
+}
+
+// probeDatabase pings the database's underlying connection pool.
+func probeDatabase(ctx context.Context, db *gorm.DB) DependencyStatus {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, dependencyProbeTimeout)
+	defer cancel()
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.WithError(err).Error("Health Check: Failed to retrieve underlying DB connection pool.")
+		return DependencyStatus{Status: "DOWN", Message: err.Error(), LatencyMS: time.Since(start).Milliseconds()}
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		log.WithError(err).Error("Health Check: Database connectivity check failed.")
+		return DependencyStatus{Status: "DOWN", Message: err.Error(), LatencyMS: time.Since(start).Milliseconds()}
+	}
+	return DependencyStatus{Status: "UP", LatencyMS: time.Since(start).Milliseconds()}
+}
+
+// probeKubeClient queries the control-plane cluster's server version -- the
+// cluster Kylon itself runs on, as opposed to clusters registered via the
+// ClusterHandler API.
+func probeKubeClient(ctx context.Context, kubeClient *kubernetes.Clientset) DependencyStatus {
+	start := time.Now()
+	version, err := kubeClient.Discovery().ServerVersion()
+	if err != nil {
+		log.WithError(err).Error("Health Check: Kubernetes API connectivity check failed.")
+		return DependencyStatus{Status: "DOWN", Message: err.Error(), LatencyMS: time.Since(start).Milliseconds()}
+	}
+	return DependencyStatus{Status: "UP", Message: version.String(), LatencyMS: time.Since(start).Milliseconds()}
+}
 
-		// --- Kubernetes Client Health Check ---
-		if kubeClient != nil {
-			ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second) // 3-second timeout for K8s API call
-			defer cancel()
-
-			// Perform a lightweight Kubernetes API call, like listing namespaces, to verify connectivity.
-			_, err := kubeClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
-			if err != nil {
-				log.WithError(err).Error("Health Check: Kubernetes API connectivity check failed.")
-				response.Dependencies.Kubernetes = fmt.Sprintf("DOWN - %s", err.Error())
-				// Only set overall status to error if it's not already an error from previous checks
-				if overallHTTPStatus == http.StatusOK {
-					overallHTTPStatus = http.StatusInternalServerError
-					overallMessage = "Service unhealthy: Kubernetes API communication failed"
-				}
+// probeCluster reports clusterID's status from clusterManager's cache when
+// available -- populated by the scheduler's periodic reconciliation pass --
+// so a health check never itself drives a live API call against every
+// registered cluster. Only when clusterManager is nil or has no entry yet
+// for clusterID (e.g. it was registered before the scheduler's first pass)
+// does it fall back to resolving a client via ClientCache and probing live.
+// An unreachable cluster is reported as DOWN in its own entry; it never
+// fails the probe of any other cluster or dependency.
+func probeCluster(ctx context.Context, clientCache *kube.ClientCache, clusterManager *services.ClusterManager, clusterID uuid.UUID, clusterName string) DependencyStatus {
+	if clusterManager != nil {
+		if live, ok := clusterManager.Get(clusterID); ok {
+			status := DependencyStatus{Message: live.ServerVersion, LatencyMS: live.LatencyMS}
+			if live.Reachable {
+				status.Status = "UP"
 			} else {
-				response.Dependencies.Kubernetes = "UP"
+				status.Status = "DOWN"
+				status.Message = live.LastError
 			}
-		} else {
-			log.Debug("Health Check: Kubernetes client not provided (nil), skipping K8s health check.")
+			return status
 		}
+	}
+
+	start := time.Now()
+	probeCtx, cancel := context.WithTimeout(ctx, dependencyProbeTimeout)
+	defer cancel()
+
+	clientset, err := clientCache.ClientFor(probeCtx, clusterID)
+	if err != nil {
+		log.WithError(err).WithField("cluster_name", clusterName).Warn("Health Check: Failed to resolve client for registered cluster.")
+		return DependencyStatus{Status: "DOWN", Message: err.Error(), LatencyMS: time.Since(start).Milliseconds()}
+	}
+
+	raw, err := clientset.Discovery().RESTClient().Get().AbsPath("/version").Do(probeCtx).Raw()
+	var info version.Info
+	if err == nil {
+		err = json.Unmarshal(raw, &info)
+	}
+	if err != nil {
+		log.WithError(err).WithField("cluster_name", clusterName).Warn("Health Check: Registered cluster is unreachable.")
+		return DependencyStatus{Status: "DOWN", Message: err.Error(), LatencyMS: time.Since(start).Milliseconds()}
+	}
+	return DependencyStatus{Status: "UP", Message: info.String(), LatencyMS: time.Since(start).Milliseconds()}
+}
+
+// probeAIEndpoint performs a short-timeout GET against the configured AI
+// model endpoint to confirm it is reachable.
+func probeAIEndpoint(ctx context.Context, endpoint string) DependencyStatus {
+	start := time.Now()
+	if endpoint == "" {
+		return DependencyStatus{Status: "DOWN", Message: "AI is enabled but modelEndpoint is not configured.", LatencyMS: time.Since(start).Milliseconds()}
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, dependencyProbeTimeout)
+	defer cancel()
 
-		// Update the overall status and message in the response based on dependency checks.
-		response.Status = http.StatusText(overallHTTPStatus)
-		response.Message = overallMessage
+	req, err := http.NewRequestWithContext(probeCtx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return DependencyStatus{Status: "DOWN", Message: err.Error(), LatencyMS: time.Since(start).Milliseconds()}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.WithError(err).Error("Health Check: AI endpoint connectivity check failed.")
+		return DependencyStatus{Status: "DOWN", Message: err.Error(), LatencyMS: time.Since(start).Milliseconds()}
+	}
+	defer resp.Body.Close()
 
-		// Send the final API response. If overall status is not OK, use ErrorResponse.
-		if overallHTTPStatus != http.StatusOK {
-			// For an unhealthy status, use the common error response utility,
-			// mapping to an internal error code and message.
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return DependencyStatus{Status: "DOWN", Message: fmt.Sprintf("unexpected status %d", resp.StatusCode), LatencyMS: time.Since(start).Milliseconds()}
+	}
+	return DependencyStatus{Status: "UP", LatencyMS: time.Since(start).Milliseconds()}
+}
+
+// buildHealthResponse runs probeDependencies and assembles the common
+// response envelope shared by HealthCheckHandler and ReadinessHandler.
+func buildHealthResponse(ctx context.Context, cfg *config.Config, db *gorm.DB, kubeClient *kubernetes.Clientset, clusterRepo repositories.ClusterRepository, clientCache *kube.ClientCache, clusterManager *services.ClusterManager) (HealthCheckResponse, bool) {
+	deps, healthy := probeDependencies(ctx, cfg, db, kubeClient, clusterRepo, clientCache, clusterManager)
+
+	status := "UP"
+	message := "Service is healthy"
+	if !healthy {
+		status = "DOWN"
+		message = "One or more dependencies are unavailable"
+	}
+
+	return HealthCheckResponse{
+		Status:       status,
+		Message:      message,
+		Application:  cfg.App.Name,
+		Version:      cfg.App.Version,
+		Environment:  cfg.App.Env,
+		Timestamp:    time.Now().Format(time.RFC3339),
+		Dependencies: deps,
+	}, healthy
+}
+
+// HealthCheckHandler returns a gin.HandlerFunc for the liveness probe
+// (/healthz). It reports the same per-dependency detail as ReadinessHandler,
+// but always responds 200 -- a cluster or the database being unreachable
+// means Kylon is not *ready* to serve that dependency's traffic, not that the
+// process itself is unhealthy, so liveness must stay green to avoid the
+// orchestrator killing the pod over a transient downstream outage.
+func HealthCheckHandler(cfg *config.Config, db *gorm.DB, kubeClient *kubernetes.Clientset, clusterRepo repositories.ClusterRepository, clientCache *kube.ClientCache, clusterManager *services.ClusterManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		response, _ := buildHealthResponse(c.Request.Context(), cfg, db, kubeClient, clusterRepo, clientCache, clusterManager)
+		utils.SuccessResponse(c, http.StatusOK, response.Message, response)
+	}
+}
+
+// ReadinessHandler returns a gin.HandlerFunc for the readiness probe
+// (/readyz). Unlike HealthCheckHandler, an unhealthy dependency degrades the
+// response to HTTP 503 via a typed ErrCodeUnavailable error, signalling that
+// traffic should not be routed to this instance until the dependency recovers.
+func ReadinessHandler(cfg *config.Config, db *gorm.DB, kubeClient *kubernetes.Clientset, clusterRepo repositories.ClusterRepository, clientCache *kube.ClientCache, clusterManager *services.ClusterManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		response, healthy := buildHealthResponse(c.Request.Context(), cfg, db, kubeClient, clusterRepo, clientCache, clusterManager)
+
+		if !healthy {
 			utils.ErrorResponse(c, customerrors.NewCustomError(
-				customerrors.ErrCodeInternal, // Application-specific internal error code
-				overallMessage,               // The summarized message for the client
-				nil,                          // No underlying specific error to wrap for this summary response
-				overallHTTPStatus,            // The HTTP status code determined by health checks
-				response,                     // Pass the full health check response as data in the error payload
+				customerrors.ErrCodeUnavailable,
+				response.Message,
+				nil,
+				http.StatusServiceUnavailable,
+				response,
 			))
-		} else {
-			// For a healthy status, use the success response utility.
-			utils.SuccessResponse(c, http.StatusOK, response.Message, response)
+			return
 		}
+
+		utils.SuccessResponse(c, http.StatusOK, response.Message, response)
 	}
 }
@@ -0,0 +1,108 @@
+/**
+ * @File: healthcheck.handler.go
+ * @Title: Cluster Healthcheck Handler
+ * @Description: Starts, retrieves and cancels Engine-run healthchecks
+ * @Description: against a registered cluster over HTTP.
+ * @Author: thesyscoder/kylon (github.com/thesyscoder)
+ */
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/thesyscoder/kylon/internal/app/utils"
+	"github.com/thesyscoder/kylon/internal/domain/healthcheck"
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+)
+
+// HealthcheckHandler handles HTTP requests for running and inspecting
+// per-cluster healthchecks.
+type HealthcheckHandler struct {
+	engine *healthcheck.Engine
+	log    *logrus.Logger
+}
+
+// NewHealthcheckHandler creates a new HealthcheckHandler.
+func NewHealthcheckHandler(engine *healthcheck.Engine, log *logrus.Logger) *HealthcheckHandler {
+	if engine == nil {
+		log.Fatal("Engine is nil when creating HealthcheckHandler. Critical setup error.")
+	}
+	return &HealthcheckHandler{engine: engine, log: log}
+}
+
+// CreateHealthcheck handles POST /api/v1/clusters/:id/healthchecks. It
+// starts the run in the background and returns 201 immediately with the
+// run's ID and RUNNING status; poll GetHealthcheck for progress.
+func (h *HealthcheckHandler) CreateHealthcheck(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	clusterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, customerrors.NewCustomError(customerrors.ErrCodeInvalidInput, "Cluster ID must be a valid UUID.", err, http.StatusBadRequest, nil))
+		return
+	}
+
+	var spec healthcheck.Spec
+	if err := c.ShouldBindJSON(&spec); err != nil {
+		utils.ErrorResponse(c, customerrors.NewCustomError(customerrors.ErrCodeInvalidInput, "Invalid healthcheck spec.", err, http.StatusBadRequest, nil))
+		return
+	}
+
+	record, err := h.engine.Start(ctx, clusterID, spec)
+	if err != nil {
+		h.log.WithContext(ctx).WithField("cluster_id", clusterID).WithError(err).Warn("Failed to start healthcheck.")
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Healthcheck started.", gin.H{
+		"id":         record.ID,
+		"cluster_id": record.ClusterID,
+		"status":     record.Status,
+	})
+}
+
+// GetHealthcheck handles GET /api/v1/clusters/:id/healthchecks/:hcid,
+// returning the run's current aggregate state and captured hook logs.
+func (h *HealthcheckHandler) GetHealthcheck(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	hcID, err := uuid.Parse(c.Param("hcid"))
+	if err != nil {
+		utils.ErrorResponse(c, customerrors.NewCustomError(customerrors.ErrCodeInvalidInput, "Healthcheck ID must be a valid UUID.", err, http.StatusBadRequest, nil))
+		return
+	}
+
+	view, err := h.engine.Get(ctx, hcID)
+	if err != nil {
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Healthcheck retrieved successfully.", view)
+}
+
+// DeleteHealthcheck handles DELETE /api/v1/clusters/:id/healthchecks/:hcid.
+// If the run is still RUNNING in this process, it cancels its context --
+// which stops its probes and cleans up any hook pods it created -- then
+// deletes its record either way.
+func (h *HealthcheckHandler) DeleteHealthcheck(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	hcID, err := uuid.Parse(c.Param("hcid"))
+	if err != nil {
+		utils.ErrorResponse(c, customerrors.NewCustomError(customerrors.ErrCodeInvalidInput, "Healthcheck ID must be a valid UUID.", err, http.StatusBadRequest, nil))
+		return
+	}
+
+	if err := h.engine.Cancel(ctx, hcID); err != nil {
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Healthcheck cancelled.", nil)
+}
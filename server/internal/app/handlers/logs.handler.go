@@ -0,0 +1,303 @@
+/**
+ * @File: logs.handler.go
+ * @Title: Pod and Container Log Streaming Handler
+ * @Description: Streams container logs from a registered cluster's stored
+ * @Description: kubeconfig, either as a live chunked response or, in
+ * @Description: download mode, bundled across every container of every pod
+ * @Description: matching a label selector into a tar.gz.
+ * @Author: thesyscoder (github.com/thesyscoder)
+ */
+
+package handlers
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/thesyscoder/kylon/internal/app/utils"
+	kube "github.com/thesyscoder/kylon/internal/infrastructure/kubernetes"
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxConcurrentStreamsPerCluster caps how many log streams (live or download)
+// may be open against a single cluster at once, so a burst of log requests
+// against one unreachable or chatty cluster can't exhaust server resources or
+// starve requests to every other registered cluster.
+const maxConcurrentStreamsPerCluster = 8
+
+// LogsHandler handles streaming and bundling of pod/container logs for
+// registered clusters.
+type LogsHandler struct {
+	clientCache *kube.ClientCache
+	log         *logrus.Logger
+
+	mu            sync.Mutex
+	activeStreams map[uuid.UUID]int
+}
+
+// NewLogsHandler creates a new LogsHandler.
+func NewLogsHandler(clientCache *kube.ClientCache, log *logrus.Logger) *LogsHandler {
+	if clientCache == nil {
+		log.Fatal("ClientCache is nil when creating LogsHandler. Critical setup error.")
+	}
+	return &LogsHandler{
+		clientCache:   clientCache,
+		log:           log,
+		activeStreams: make(map[uuid.UUID]int),
+	}
+}
+
+// acquireStream reserves one of clusterID's concurrent-stream slots, or
+// reports false if the cluster is already at maxConcurrentStreamsPerCluster.
+func (h *LogsHandler) acquireStream(clusterID uuid.UUID) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.activeStreams[clusterID] >= maxConcurrentStreamsPerCluster {
+		return false
+	}
+	h.activeStreams[clusterID]++
+	return true
+}
+
+// releaseStream frees clusterID's reserved slot.
+func (h *LogsHandler) releaseStream(clusterID uuid.UUID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.activeStreams[clusterID]--
+	if h.activeStreams[clusterID] <= 0 {
+		delete(h.activeStreams, clusterID)
+	}
+}
+
+// StreamPodLogs handles GET /api/v1/clusters/:id/namespaces/:ns/pods/:pod/logs.
+//
+// Query parameters: container, follow, tailLines, sinceSeconds, previous
+// control a single container's log stream, exactly mirroring `kubectl logs`.
+// download=true instead bundles the logs of every container of every pod
+// matching the `selector` label selector into a tar.gz attachment.
+//
+// Streaming responses bypass the standard APIResponse envelope entirely and
+// write chunks straight to c.Writer with chunked transfer encoding; only
+// errors that occur before the first byte is written still flow through
+// utils.ErrorResponse.
+func (h *LogsHandler) StreamPodLogs(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	clusterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, customerrors.NewCustomError(customerrors.ErrCodeInvalidInput, "Cluster ID must be a valid UUID.", err, http.StatusBadRequest, nil))
+		return
+	}
+
+	if !h.acquireStream(clusterID) {
+		utils.ErrorResponse(c, customerrors.NewCustomError(
+			customerrors.ErrCodeRateLimitExceeded,
+			fmt.Sprintf("Too many concurrent log streams for cluster '%s'; try again shortly.", clusterID),
+			nil,
+			http.StatusTooManyRequests,
+			nil,
+		))
+		return
+	}
+	defer h.releaseStream(clusterID)
+
+	clientset, err := h.clientCache.ClientFor(ctx, clusterID)
+	if err != nil {
+		h.log.WithContext(ctx).WithField("cluster_id", clusterID).WithError(err).Error("Failed to resolve Kubernetes client for log stream.")
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	namespace := c.Param("ns")
+	podName := c.Param("pod")
+
+	if c.Query("download") == "true" {
+		h.downloadPodLogs(c, clientset, namespace, c.Query("selector"))
+		return
+	}
+
+	h.streamContainerLogs(c, clientset, namespace, podName)
+}
+
+// streamContainerLogs handles the default (non-download) mode: a single
+// container's log stream, flushed to the client as it arrives.
+func (h *LogsHandler) streamContainerLogs(c *gin.Context, clientset *kubernetes.Clientset, namespace, podName string) {
+	ctx := c.Request.Context()
+
+	opts, err := parsePodLogOptions(c)
+	if err != nil {
+		utils.ErrorResponse(c, customerrors.NewCustomError(customerrors.ErrCodeInvalidInput, err.Error(), err, http.StatusBadRequest, nil))
+		return
+	}
+
+	stream, err := clientset.CoreV1().Pods(namespace).GetLogs(podName, opts).Stream(ctx)
+	if err != nil {
+		h.log.WithContext(ctx).WithField("pod", podName).WithError(err).Warn("Failed to open log stream.")
+		utils.ErrorResponse(c, customerrors.NewCustomError(
+			customerrors.ErrCodeK8sLogStreamFailed,
+			fmt.Sprintf("Failed to stream logs for pod '%s'.", podName),
+			err,
+			http.StatusUnprocessableEntity,
+			nil,
+		))
+		return
+	}
+	defer stream.Close()
+
+	c.Header("Content-Type", "text/plain; charset=utf-8")
+	c.Header("Transfer-Encoding", "chunked")
+	c.Status(http.StatusOK)
+
+	flusher, _ := c.Writer.(http.Flusher)
+	buf := make([]byte, 4096)
+	for {
+		select {
+		case <-ctx.Done():
+			h.log.WithContext(ctx).WithField("pod", podName).Info("Log stream cancelled; client disconnected.")
+			return
+		default:
+		}
+
+		n, readErr := stream.Read(buf)
+		if n > 0 {
+			if _, writeErr := c.Writer.Write(buf[:n]); writeErr != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		if readErr != nil {
+			if readErr != io.EOF {
+				h.log.WithContext(ctx).WithField("pod", podName).WithError(readErr).Warn("Log stream ended with an error.")
+			}
+			return
+		}
+	}
+}
+
+// downloadPodLogs lists every pod matching selector in namespace and bundles
+// every container's logs (non-follow) into a tar.gz attachment.
+func (h *LogsHandler) downloadPodLogs(c *gin.Context, clientset *kubernetes.Clientset, namespace, selector string) {
+	ctx := c.Request.Context()
+
+	opts, err := parsePodLogOptions(c)
+	if err != nil {
+		utils.ErrorResponse(c, customerrors.NewCustomError(customerrors.ErrCodeInvalidInput, err.Error(), err, http.StatusBadRequest, nil))
+		return
+	}
+	opts.Follow = false // a tar.gz bundle only ever makes sense for a bounded log, never a live tail
+
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		h.log.WithContext(ctx).WithField("namespace", namespace).WithError(err).Warn("Failed to list pods for log download.")
+		utils.ErrorResponse(c, customerrors.NewCustomError(
+			customerrors.ErrCodeK8sLogStreamFailed,
+			fmt.Sprintf("Failed to list pods matching selector '%s'.", selector),
+			err,
+			http.StatusUnprocessableEntity,
+			nil,
+		))
+		return
+	}
+
+	filename := fmt.Sprintf("%s-logs-%d.tar.gz", namespace, time.Now().Unix())
+	c.Header("Content-Type", "application/gzip")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	gz := gzip.NewWriter(c.Writer)
+	defer gz.Close()
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+
+	for _, pod := range pods.Items {
+		containers := opts.Container
+		containerNames := make([]string, 0, len(pod.Spec.Containers))
+		if containers != "" {
+			containerNames = append(containerNames, containers)
+		} else {
+			for _, container := range pod.Spec.Containers {
+				containerNames = append(containerNames, container.Name)
+			}
+		}
+
+		for _, containerName := range containerNames {
+			if ctx.Err() != nil {
+				return
+			}
+
+			containerOpts := *opts
+			containerOpts.Container = containerName
+
+			data, err := fetchContainerLogs(ctx, clientset, namespace, pod.Name, &containerOpts)
+			if err != nil {
+				h.log.WithContext(ctx).WithField("pod", pod.Name).WithField("container", containerName).WithError(err).Warn("Failed to fetch logs for container; skipping it in the bundle.")
+				continue
+			}
+
+			header := &tar.Header{
+				Name: fmt.Sprintf("%s/%s.log", pod.Name, containerName),
+				Mode: 0600,
+				Size: int64(len(data)),
+			}
+			if err := tw.WriteHeader(header); err != nil {
+				return
+			}
+			if _, err := tw.Write(data); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// fetchContainerLogs reads a single container's complete log output.
+func fetchContainerLogs(ctx context.Context, clientset *kubernetes.Clientset, namespace, podName string, opts *corev1.PodLogOptions) ([]byte, error) {
+	stream, err := clientset.CoreV1().Pods(namespace).GetLogs(podName, opts).Stream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+	return io.ReadAll(stream)
+}
+
+// parsePodLogOptions builds a corev1.PodLogOptions from the request's
+// container, follow, tailLines, sinceSeconds and previous query parameters.
+func parsePodLogOptions(c *gin.Context) (*corev1.PodLogOptions, error) {
+	opts := &corev1.PodLogOptions{
+		Container: c.Query("container"),
+		Follow:    c.Query("follow") == "true",
+		Previous:  c.Query("previous") == "true",
+	}
+
+	if raw := c.Query("tailLines"); raw != "" {
+		tailLines, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("tailLines must be an integer: %w", err)
+		}
+		opts.TailLines = &tailLines
+	}
+
+	if raw := c.Query("sinceSeconds"); raw != "" {
+		sinceSeconds, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("sinceSeconds must be an integer: %w", err)
+		}
+		opts.SinceSeconds = &sinceSeconds
+	}
+
+	return opts, nil
+}
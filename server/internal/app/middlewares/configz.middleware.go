@@ -0,0 +1,58 @@
+/**
+ * @File: configz.middleware.go
+ * @Title: Configz Access Gate
+ * @Description: Restricts access to the /configz surface so the runtime
+ * @Description: configuration snapshot (even redacted) is never exposed by
+ * @Description: default in production.
+ * @Author: thesyscoder (github.com/thesyscoder)
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thesyscoder/kylon/internal/app/utils"
+	"github.com/thesyscoder/kylon/internal/infrastructure/config"
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+)
+
+// configzTokenEnv names the environment variable holding the bearer token
+// that unlocks /configz in production. Left unset (the default), production
+// can never present a matching token and the endpoint stays fully gated.
+const configzTokenEnv = "CONFIGZ_AUTH_TOKEN"
+
+// ConfigzAuthMiddleware gates /configz and its subroutes: outside production
+// it is always reachable for operator debugging; in production it
+// additionally requires a "Bearer <token>" Authorization header matching
+// configzTokenEnv.
+func ConfigzAuthMiddleware(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cfg.App.Env != "production" {
+			c.Next()
+			return
+		}
+
+		token := os.Getenv(configzTokenEnv)
+		presented := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if token == "" || presented == "" || presented != token {
+			utils.ErrorResponse(
+				c,
+				customerrors.NewCustomError(
+					customerrors.ErrCodeUnauthorized,
+					"configz is disabled in production without a valid bearer token.",
+					nil,
+					http.StatusUnauthorized,
+					nil,
+				),
+			)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
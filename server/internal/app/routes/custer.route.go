@@ -5,22 +5,43 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/thesyscoder/kylon/internal/app/handlers"
 	"github.com/thesyscoder/kylon/internal/app/services"
+	"github.com/thesyscoder/kylon/internal/app/tracker"
+	"github.com/thesyscoder/kylon/internal/domain/healthcheck"
+	"github.com/thesyscoder/kylon/internal/infrastructure/config"
+	kube "github.com/thesyscoder/kylon/internal/infrastructure/kubernetes"
 	"github.com/thesyscoder/kylon/internal/infrastructure/repositories"
+	"github.com/thesyscoder/kylon/pkg/secrets"
 	"gorm.io/gorm"
 )
 
-func RegisterClusterRoutes(rg *gin.RouterGroup, db *gorm.DB, log *logrus.Logger) {
+func RegisterClusterRoutes(rg *gin.RouterGroup, db *gorm.DB, log *logrus.Logger, clientCache *kube.ClientCache, clusterManager *services.ClusterManager, keyService secrets.KeyService, cfg *config.Config) {
 	clusters := rg.Group("/clusters")
 	{
 		// call the dependecies
 		clusterRepo := repositories.NewClusterRepository(db, log)
-		clusterService := services.NewClusterService(clusterRepo, log)
-		clusterHandler := handlers.NewClusterHandler(clusterService, log)
+		clusterService := services.NewClusterService(clusterRepo, clusterManager, log)
+		clusterHandler := handlers.NewClusterHandler(clusterService, clientCache, keyService, cfg.Kubernetes.KubeconfigSaveDir, log)
+		logsHandler := handlers.NewLogsHandler(clientCache, log)
+		resourceTracker := tracker.NewTracker(clientCache, log)
+		bundleHandler := handlers.NewBundleHandler(resourceTracker, log)
+		healthcheckRepo := repositories.NewHealthcheckRepository(db, log)
+		healthcheckEngine := healthcheck.NewEngine(clientCache, healthcheckRepo, log)
+		healthcheckHandler := handlers.NewHealthcheckHandler(healthcheckEngine, log)
 
 		clusters.POST("", clusterHandler.RegisterCluster)
-		clusters.GET("", clusterHandler.ListClusters) // e.g., GET /api/v1/clusters
+		clusters.POST("/in-cluster", clusterHandler.RegisterInClusterCluster)            // e.g., POST /api/v1/clusters/in-cluster
+		clusters.POST("/token", clusterHandler.RegisterClusterFromToken)                 // e.g., POST /api/v1/clusters/token
+		clusters.GET("", clusterHandler.ListClusters)                                    // e.g., GET /api/v1/clusters
+		clusters.GET("/:id/pods", clusterHandler.ListPods)                               // e.g., GET /api/v1/clusters/:id/pods
+		clusters.GET("/:id/status", clusterHandler.GetClusterStatus)                     // e.g., GET /api/v1/clusters/:id/status
+		clusters.GET("/:id/namespaces/:ns/pods/:pod/logs", logsHandler.StreamPodLogs)    // e.g., GET /api/v1/clusters/:id/namespaces/:ns/pods/:pod/logs
+		clusters.GET("/:id/bundles/:app/status", bundleHandler.GetBundleStatus)          // e.g., GET /api/v1/clusters/:id/bundles/:app/status
+		clusters.POST("/:id/bundles/:app/apply", bundleHandler.ApplyBundle)              // e.g., POST /api/v1/clusters/:id/bundles/:app/apply
+		clusters.POST("/:id/healthchecks", healthcheckHandler.CreateHealthcheck)         // e.g., POST /api/v1/clusters/:id/healthchecks
+		clusters.GET("/:id/healthchecks/:hcid", healthcheckHandler.GetHealthcheck)       // e.g., GET /api/v1/clusters/:id/healthchecks/:hcid
+		clusters.DELETE("/:id/healthchecks/:hcid", healthcheckHandler.DeleteHealthcheck) // e.g., DELETE /api/v1/clusters/:id/healthchecks/:hcid
 
-		log.Info("Cluster API routes registered successfully: /api/v1/clusters (POST, GET)")
+		log.Info("Cluster API routes registered successfully: /api/v1/clusters (POST, POST /in-cluster, POST /token, GET, GET /:id/pods, GET /:id/status, GET /:id/namespaces/:ns/pods/:pod/logs, GET /:id/bundles/:app/status, POST /:id/bundles/:app/apply, POST /:id/healthchecks, GET /:id/healthchecks/:hcid, DELETE /:id/healthchecks/:hcid)")
 
 	}
 }
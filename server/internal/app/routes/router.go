@@ -1,23 +1,73 @@
 package routes
 
 import (
+	"net/http/pprof"
+
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	"github.com/thesyscoder/kylon/internal/app/handlers"
 	middleware "github.com/thesyscoder/kylon/internal/app/middlewares"
+	"github.com/thesyscoder/kylon/internal/app/services"
 	"github.com/thesyscoder/kylon/internal/infrastructure/config"
+	kube "github.com/thesyscoder/kylon/internal/infrastructure/kubernetes"
+	"github.com/thesyscoder/kylon/internal/infrastructure/repositories"
+	"github.com/thesyscoder/kylon/pkg/configz"
+	"github.com/thesyscoder/kylon/pkg/secrets"
 	"gorm.io/gorm"
 	"k8s.io/client-go/kubernetes"
 )
 
-func InitializeRoutes(cfg *config.Config, db *gorm.DB, log *logrus.Logger, kubeClient *kubernetes.Clientset) *gin.Engine {
+func InitializeRoutes(cfg *config.Config, db *gorm.DB, log *logrus.Logger, kubeClient *kubernetes.Clientset, clientCache *kube.ClientCache, clusterManager *services.ClusterManager, keyService secrets.KeyService) *gin.Engine {
 	router := gin.Default()
 	router.Use(middleware.CorsMiddleware())
+
+	// Observability surface: liveness/readiness/dependency health, the
+	// effective (redacted) runtime configuration, and -- opt-in -- pprof
+	// profiling.
+	clusterRepo := repositories.NewClusterRepository(db, log)
+	router.GET("/healthz", handlers.HealthCheckHandler(cfg, db, kubeClient, clusterRepo, clientCache, clusterManager))
+	router.GET("/readyz", handlers.ReadinessHandler(cfg, db, kubeClient, clusterRepo, clientCache, clusterManager))
+
+	// configz is gated behind ConfigzAuthMiddleware: always reachable outside
+	// production, bearer-token-gated in it, since even a redacted runtime
+	// configuration snapshot shouldn't be exposed by default.
+	configzGroup := router.Group("/", middleware.ConfigzAuthMiddleware(cfg))
+	configz.InstallHandler(configzGroup)
+	configzGroup.POST("/configz/log-level", handlers.LogLevelHandler())
+
+	if cfg.App.EnableProfiling {
+		registerPprofRoutes(router)
+	}
+
 	apiV1 := router.Group("/api/v1")
 	{
-		apiV1.GET("healthz", handlers.SetupHealthCheckHandler())
+		apiV1.GET("healthz", handlers.HealthCheckHandler(cfg, db, kubeClient, clusterRepo, clientCache, clusterManager))
+		apiV1.GET("readyz", handlers.ReadinessHandler(cfg, db, kubeClient, clusterRepo, clientCache, clusterManager))
+
+		apiV1Configz := apiV1.Group("/", middleware.ConfigzAuthMiddleware(cfg))
+		configz.InstallHandler(apiV1Configz)
+		apiV1Configz.POST("/configz/log-level", handlers.LogLevelHandler())
 	}
 	// Clusters (call RegisterClusterRoutes)
-	RegisterClusterRoutes(apiV1, cfg, db, log)
+	RegisterClusterRoutes(apiV1, db, log, clientCache, clusterManager, keyService, cfg)
 	return router
 }
+
+// registerPprofRoutes mounts the standard net/http/pprof endpoints under
+// /debug/pprof/*. It is only called when cfg.App.EnableProfiling is set, since
+// pprof exposes runtime internals (goroutine stacks, heap contents) that
+// should never be reachable in an untrusted environment.
+func registerPprofRoutes(router *gin.Engine) {
+	debug := router.Group("/debug/pprof")
+	{
+		debug.GET("/", gin.WrapF(pprof.Index))
+		debug.GET("/cmdline", gin.WrapF(pprof.Cmdline))
+		debug.GET("/profile", gin.WrapF(pprof.Profile))
+		debug.POST("/symbol", gin.WrapF(pprof.Symbol))
+		debug.GET("/symbol", gin.WrapF(pprof.Symbol))
+		debug.GET("/trace", gin.WrapF(pprof.Trace))
+		debug.GET("/:name", func(c *gin.Context) {
+			pprof.Handler(c.Param("name")).ServeHTTP(c.Writer, c.Request)
+		})
+	}
+}
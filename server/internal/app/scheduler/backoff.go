@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// baseBackoff and maxBackoff bound the exponential backoff applied to a
+// cluster that fails reconciliation repeatedly, so a persistently unreachable
+// cluster is not re-probed on every single tick.
+const (
+	baseBackoff = 30 * time.Second
+	maxBackoff  = 15 * time.Minute
+)
+
+// invalidateAfterFailures is the number of consecutive reconciliation
+// failures after which recordFailure drops the cluster's cached clientset,
+// forcing a rebuild from its current stored kubeconfig. A single failed
+// probe is usually just a transient network blip; this only kicks in once a
+// cluster has failed repeatedly, which is also consistent with a stale
+// client built from credentials that have since rotated or stopped working.
+const invalidateAfterFailures = 3
+
+// backoffState tracks consecutive reconciliation failures for a single
+// cluster.
+type backoffState struct {
+	failures int
+	until    time.Time
+}
+
+// backoffFor returns the current backoffState for clusterID, creating one on
+// first use. Callers must hold s.mu.
+func (s *Scheduler) backoffFor(clusterID uuid.UUID) *backoffState {
+	b, ok := s.backoff[clusterID]
+	if !ok {
+		b = &backoffState{}
+		s.backoff[clusterID] = b
+	}
+	return b
+}
+
+// shouldSkip reports whether clusterID is still within its backoff window.
+func (s *Scheduler) shouldSkip(clusterID uuid.UUID, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return now.Before(s.backoffFor(clusterID).until)
+}
+
+// recordFailure lengthens clusterID's backoff window exponentially, capped at
+// maxBackoff. Once failures reach invalidateAfterFailures, it also drops the
+// cluster's cached clientset via ClientCache.Invalidate and resets the
+// failure count, so the next attempt rebuilds a fresh client (and restarts
+// the backoff ramp) instead of retrying the same possibly-stale client
+// forever.
+func (s *Scheduler) recordFailure(clusterID uuid.UUID, now time.Time) {
+	s.mu.Lock()
+	b := s.backoffFor(clusterID)
+	b.failures++
+	delay := baseBackoff * time.Duration(1<<uint(b.failures-1))
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	b.until = now.Add(delay)
+
+	invalidate := b.failures >= invalidateAfterFailures
+	if invalidate {
+		b.failures = 0
+	}
+	s.mu.Unlock()
+
+	if invalidate {
+		s.clientCache.Invalidate(clusterID)
+	}
+}
+
+// recordSuccess clears clusterID's backoff state after a successful probe.
+func (s *Scheduler) recordSuccess(clusterID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.backoff, clusterID)
+}
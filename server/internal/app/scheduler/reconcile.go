@@ -0,0 +1,134 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thesyscoder/kylon/internal/app/services"
+	"github.com/thesyscoder/kylon/internal/domain/models"
+	"github.com/thesyscoder/kylon/internal/infrastructure/repositories"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+// clusterTimeout bounds how long a single cluster's reconciliation probe may
+// take before it is considered unreachable for this pass.
+const clusterTimeout = 10 * time.Second
+
+// maxJitter is the upper bound of the random pause inserted between clusters
+// within a single pass, so a large fleet of clusters doesn't fire off a burst
+// of simultaneous probes.
+const maxJitter = 2 * time.Second
+
+// reconcileOnce lists every registered cluster and refreshes each one's
+// status in turn. A single cluster's failure is logged and recorded against
+// that cluster only; it never aborts the rest of the pass.
+func (s *Scheduler) reconcileOnce(ctx context.Context) {
+	clusters, err := s.clusterRepo.List(ctx)
+	if err != nil {
+		s.log.WithError(err).Error("Cluster reconciliation pass aborted; failed to list clusters.")
+		return
+	}
+
+	s.log.WithField("cluster_count", len(clusters)).Debug("Starting cluster reconciliation pass.")
+
+	for i, cluster := range clusters {
+		if ctx.Err() != nil {
+			s.log.Info("Cluster reconciliation pass cancelled; stopping early.")
+			return
+		}
+
+		if s.shouldSkip(cluster.ID, time.Now()) {
+			s.log.WithField("cluster_id", cluster.ID).Debug("Skipping cluster; still within backoff window.")
+			continue
+		}
+
+		s.reconcileCluster(ctx, cluster)
+
+		if i < len(clusters)-1 {
+			jitter := time.Duration(rand.Int63n(int64(maxJitter)))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(jitter):
+			}
+		}
+	}
+}
+
+// reconcileCluster probes a single cluster and persists the outcome via
+// ClusterRepository.UpdateClusterStatus, recording or clearing backoff for it
+// depending on the result.
+func (s *Scheduler) reconcileCluster(ctx context.Context, cluster models.Cluster) {
+	clusterCtx, cancel := context.WithTimeout(ctx, clusterTimeout)
+	defer cancel()
+
+	probeStart := time.Now()
+	now := probeStart
+	status := repositories.ClusterStatusUpdate{SyncedAt: now}
+
+	clientset, err := s.clientCache.ClientFor(clusterCtx, cluster.ID)
+	if err != nil {
+		s.log.WithContext(ctx).WithField("cluster_id", cluster.ID).WithError(err).Warn("Failed to resolve Kubernetes client during reconciliation.")
+		status.LastError = err.Error()
+		s.finishCluster(ctx, cluster.ID, status, probeStart)
+		return
+	}
+
+	raw, err := clientset.Discovery().RESTClient().Get().AbsPath("/version").Do(clusterCtx).Raw()
+	var info version.Info
+	if err == nil {
+		err = json.Unmarshal(raw, &info)
+	}
+	if err != nil {
+		s.log.WithContext(ctx).WithField("cluster_id", cluster.ID).WithError(err).Warn("Cluster unreachable during reconciliation.")
+		status.LastError = err.Error()
+		s.finishCluster(ctx, cluster.ID, status, probeStart)
+		return
+	}
+	status.ServerVersion = info.String()
+
+	nodes, err := clientset.CoreV1().Nodes().List(clusterCtx, metav1.ListOptions{})
+	if err != nil {
+		s.log.WithContext(ctx).WithField("cluster_id", cluster.ID).WithError(err).Warn("Failed to list nodes during reconciliation.")
+		status.LastError = err.Error()
+		s.finishCluster(ctx, cluster.ID, status, probeStart)
+		return
+	}
+	status.NodeCount = len(nodes.Items)
+	status.HealthyAt = &now
+
+	s.finishCluster(ctx, cluster.ID, status, probeStart)
+}
+
+// finishCluster persists status via ClusterRepository.UpdateClusterStatus,
+// records or clears clusterID's backoff state depending on whether the probe
+// succeeded (status.LastError is empty on success), and -- when a
+// ClusterManager was configured -- updates its in-memory live status too,
+// so the rest of the application can read this pass's outcome without
+// re-probing the cluster or round-tripping to the database.
+func (s *Scheduler) finishCluster(ctx context.Context, clusterID uuid.UUID, status repositories.ClusterStatusUpdate, probeStart time.Time) {
+	if err := s.clusterRepo.UpdateClusterStatus(ctx, clusterID, status); err != nil {
+		s.log.WithContext(ctx).WithField("cluster_id", clusterID).WithError(err).Error("Failed to persist cluster reconciliation status.")
+	}
+
+	now := time.Now()
+	if status.LastError == "" {
+		s.recordSuccess(clusterID)
+	} else {
+		s.recordFailure(clusterID, now)
+	}
+
+	if s.clusterManager != nil {
+		s.clusterManager.Update(clusterID, services.ClusterLiveStatus{
+			Reachable:     status.LastError == "",
+			ServerVersion: status.ServerVersion,
+			LastError:     status.LastError,
+			LastCheckedAt: now,
+			LatencyMS:     now.Sub(probeStart).Milliseconds(),
+		})
+	}
+}
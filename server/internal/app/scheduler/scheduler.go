@@ -0,0 +1,148 @@
+/**
+ * @File: scheduler.go
+ * @Title: Cluster Reconciliation Scheduler
+ * @Description: Runs a background job, on the interval configured by
+ * @Description: config.SchedulerConfig.IntervalMinutes, that walks every
+ * @Description: registered cluster and refreshes its reconciliation status
+ * @Description: (reachability, server version, node count, last-sync
+ * @Description: timestamp, last error) -- mirroring the "crossplane resources
+ * @Description: sync" pattern of an agent running registered sync jobs on a
+ * @Description: fixed interval and persisting results back to the store.
+ * @Author: thesyscoder/kylon (github.com/thesyscoder)
+ */
+
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/thesyscoder/kylon/internal/app/services"
+	"github.com/thesyscoder/kylon/internal/infrastructure/config"
+	kube "github.com/thesyscoder/kylon/internal/infrastructure/kubernetes"
+	"github.com/thesyscoder/kylon/internal/infrastructure/repositories"
+)
+
+// defaultInterval is used when SchedulerConfig.IntervalMinutes is unset or
+// non-positive.
+const defaultInterval = 5 * time.Minute
+
+// defaultShutdownTimeout bounds Stop's wait for an in-flight pass when
+// AppConfig.ShutdownTimeout is unset or non-positive.
+const defaultShutdownTimeout = 5 * time.Second
+
+// Scheduler periodically reconciles every registered cluster's status. It is
+// resilient to individual cluster failures: one cluster's error never stops
+// the sweep over the rest, and a failing cluster backs off exponentially
+// instead of being re-probed every single pass.
+type Scheduler struct {
+	clusterRepo repositories.ClusterRepository
+	// clientCache resolves (and caches) a Kubernetes Clientset per cluster
+	// from its decrypted kubeconfig -- the scheduler never decrypts or builds
+	// clients itself, so a reconciled cluster's client is immediately ready
+	// for the rest of the application to reuse (e.g. ListPods).
+	clientCache *kube.ClientCache
+	// clusterManager, when non-nil, is updated with each cluster's latest
+	// reconciliation outcome (including round-trip latency) so the rest of
+	// the application can read a cluster's live status without re-probing it
+	// or round-tripping to the database; see services.ClusterManager.
+	clusterManager *services.ClusterManager
+	log            *logrus.Logger
+
+	interval        time.Duration
+	shutdownTimeout time.Duration
+
+	mu      sync.Mutex
+	running bool
+	backoff map[uuid.UUID]*backoffState
+}
+
+// NewScheduler creates a Scheduler that reconciles every registered cluster
+// every cfg.Scheduler.IntervalMinutes (defaulting to defaultInterval), and
+// gives an in-flight pass up to cfg.App.ShutdownTimeout to finish when Start's
+// context is cancelled. clusterManager may be nil, in which case live status
+// is simply not cached anywhere beyond the database.
+func NewScheduler(clusterRepo repositories.ClusterRepository, clientCache *kube.ClientCache, clusterManager *services.ClusterManager, cfg *config.Config, log *logrus.Logger) *Scheduler {
+	if clusterRepo == nil {
+		log.Fatal("ClusterRepository is nil when creating Scheduler. Critical setup error.")
+	}
+	if clientCache == nil {
+		log.Fatal("ClientCache is nil when creating Scheduler. Critical setup error.")
+	}
+
+	interval := defaultInterval
+	if cfg.Scheduler.IntervalMinutes > 0 {
+		interval = time.Duration(cfg.Scheduler.IntervalMinutes) * time.Minute
+	}
+
+	shutdownTimeout := cfg.App.ShutdownTimeout
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = defaultShutdownTimeout
+	}
+
+	return &Scheduler{
+		clusterRepo:     clusterRepo,
+		clientCache:     clientCache,
+		clusterManager:  clusterManager,
+		log:             log,
+		interval:        interval,
+		shutdownTimeout: shutdownTimeout,
+		backoff:         make(map[uuid.UUID]*backoffState),
+	}
+}
+
+// Start runs the reconciliation loop until ctx is cancelled. Each tick spawns
+// a reconciliation pass in its own goroutine so a slow pass never delays the
+// ticker; if a pass is still running when the next tick fires, that tick is
+// skipped rather than running two overlapping passes. When ctx is cancelled,
+// Start waits up to s.shutdownTimeout for any in-flight pass to finish before
+// returning.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.log.WithField("interval", s.interval).Info("Starting cluster reconciliation scheduler.")
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	var wg sync.WaitGroup
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.Info("Cluster reconciliation scheduler stopping; waiting for any in-flight pass to finish.")
+			done := make(chan struct{})
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+				s.log.Info("Cluster reconciliation scheduler stopped cleanly.")
+			case <-time.After(s.shutdownTimeout):
+				s.log.Warn("Cluster reconciliation scheduler stop timed out; exiting with a pass still in flight.")
+			}
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			if s.running {
+				s.mu.Unlock()
+				s.log.Warn("Skipping reconciliation tick; previous pass is still running.")
+				continue
+			}
+			s.running = true
+			s.mu.Unlock()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() {
+					s.mu.Lock()
+					s.running = false
+					s.mu.Unlock()
+				}()
+				s.reconcileOnce(ctx)
+			}()
+		}
+	}
+}
@@ -0,0 +1,99 @@
+/**
+ * @File: autoregister.go
+ * @Title: In-Cluster Self-Registration
+ * @Description: At startup, optionally registers the cluster Kylon itself is
+ * @Description: running on, reusing the same synthesized-kubeconfig path the
+ * @Description: /clusters/in-cluster API endpoint uses. Re-running it against
+ * @Description: an already-registered in-cluster entry refreshes that row in
+ * @Description: place rather than inserting a duplicate under the same name.
+ * @Author: thesyscoder/kylon (github.com/thesyscoder)
+ */
+
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	"github.com/thesyscoder/kylon/internal/domain/types"
+	kube "github.com/thesyscoder/kylon/internal/infrastructure/kubernetes"
+	"github.com/thesyscoder/kylon/internal/infrastructure/repositories"
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+	"github.com/thesyscoder/kylon/pkg/secrets"
+)
+
+// inClusterName is the well-known cluster name used when self-registering via
+// AutoRegisterInCluster, matching the name operators would otherwise pass to
+// POST /api/v1/clusters/in-cluster.
+const inClusterName = "in-cluster"
+
+// AutoRegisterInCluster synthesizes a kubeconfig from rest.InClusterConfig()
+// and registers it under inClusterName, exactly as RegisterInClusterCluster
+// does for an operator-initiated request. It is meant to be called once at
+// startup when cfg.Cluster.AutoRegisterInCluster is set; outside a Kubernetes
+// pod it fails harmlessly (InClusterConfig() requires the service account
+// token/CA that are only mounted inside one), so the caller should log and
+// continue rather than treat its error as fatal.
+//
+// Because Cluster.Name carries a unique index, a plain RegisterCluster call
+// would hit a unique-constraint violation on every restart after the first.
+// AutoRegisterInCluster instead looks up the existing inClusterName row first
+// and, if found, refreshes its kubeconfig and probe status in place via
+// ClusterRepository.UpdateKubeconfig; only a first-ever run creates a new row.
+// If the probe against the synthesized kubeconfig fails, the cluster is still
+// registered/refreshed -- in a degraded/unreachable state, same as force=true
+// on the HTTP endpoint -- since the cluster obviously exists and a later
+// scheduler pass may recover it.
+//
+// When refreshing an existing row, clientCache is invalidated for it too, so
+// a previously-cached clientset built from the old kubeconfig is never reused
+// after this restart replaces it.
+func AutoRegisterInCluster(ctx context.Context, clusterRepo repositories.ClusterRepository, clusterService ClusterService, keyService secrets.KeyService, clientCache *kube.ClientCache, log *logrus.Logger) error {
+	plaintext, err := kube.SynthesizeInClusterKubeconfig()
+	if err != nil {
+		return err
+	}
+
+	probe, probeErr := ProbeKubeconfig(ctx, plaintext)
+	if probeErr != nil {
+		log.WithError(probeErr).Warn("Auto-registering in-cluster cluster in a degraded state; its kubeconfig failed validation or reachability probing.")
+	}
+
+	ciphertext, dekID, err := keyService.Encrypt(ctx, plaintext)
+	if err != nil {
+		return err
+	}
+
+	existing, err := clusterRepo.GetByName(ctx, inClusterName)
+	if err != nil {
+		var customErr *customerrors.CustomError
+		if !errors.As(err, &customErr) || customErr.Code != customerrors.ErrCodeResourceNotFound {
+			return err
+		}
+
+		cluster, err := clusterService.RegisterCluster(ctx, inClusterName, ciphertext, dekID, probe, probeErr, types.ClusterSourceInCluster)
+		if err != nil {
+			return err
+		}
+		log.WithField("cluster_id", cluster.ID).Info("Self-registered the in-cluster Kubernetes cluster at startup.")
+		return nil
+	}
+
+	probeUpdate := repositories.ClusterProbeUpdate{}
+	if probe != nil {
+		probeUpdate.ServerVersion = probe.ServerVersion
+		probeUpdate.APIEndpoint = probe.APIEndpoint
+		probeUpdate.HealthyAt = &probe.ProbedAt
+	}
+	if probeErr != nil {
+		probeUpdate.LastError = probeErr.Error()
+	}
+
+	if err := clusterRepo.UpdateKubeconfig(ctx, existing.ID, ciphertext, dekID, probeUpdate); err != nil {
+		return err
+	}
+	clientCache.Invalidate(existing.ID)
+	log.WithField("cluster_id", existing.ID).Info("Refreshed the existing in-cluster Kubernetes cluster registration at startup.")
+	return nil
+}
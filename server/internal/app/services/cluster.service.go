@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time" // Keep time imported as it's used for formatting
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/thesyscoder/kylon/internal/domain/models"
 	"github.com/thesyscoder/kylon/internal/domain/types" // IMPORTANT: Keep this import
@@ -16,37 +17,56 @@ import (
 
 // ClusterService defines the interface for cluster-related business operations.
 type ClusterService interface {
-	// Kubeconfig parameter now represents the *content* of the kubeconfig file, not its path.
-	RegisterCluster(ctx context.Context, name, kubeconfigContent string) (*models.Cluster, error)
+	// kubeconfigCiphertext/dekID are the already envelope-encrypted kubeconfig
+	// produced by the handler via pkg/secrets; the service never sees plaintext.
+	// probe is the result of validating and reachability-probing the
+	// kubeconfig (see ProbeKubeconfig) before it was encrypted; probeErr is
+	// that probe's error, non-nil when the caller chose to register anyway
+	// (force=true) despite a failed probe -- the cluster is then persisted in
+	// a degraded/unreachable state rather than rejected. source records how
+	// the kubeconfig was obtained (see types.ClusterSource).
+	RegisterCluster(ctx context.Context, name string, kubeconfigCiphertext []byte, dekID string, probe *KubeconfigProbeResult, probeErr error, source types.ClusterSource) (*models.Cluster, error)
 	// <--- UPDATED: Interface method now returns []types.ClusterSummary
 	ListClusters(ctx context.Context) ([]types.ClusterSummary, error)
+	// GetClusterStatus returns the most recent reconciliation status for a
+	// single cluster, as last refreshed by the background scheduler.
+	GetClusterStatus(ctx context.Context, id uuid.UUID) (*types.ClusterStatus, error)
 }
 
 // ClusterServiceImpl provides the implementation of ClusterService.
 type ClusterServiceImpl struct {
 	clusterRepo repositories.ClusterRepository
-	log         *logrus.Logger
+	// clusterManager, when non-nil, decorates ListClusters with each
+	// cluster's live status as last cached by the scheduler, instead of
+	// relying solely on what was last persisted to the database; see
+	// ClusterManager.
+	clusterManager *ClusterManager
+	log            *logrus.Logger
 }
 
-// NewClusterService creates a new ClusterServiceImpl.
-func NewClusterService(clusterRepo repositories.ClusterRepository, log *logrus.Logger) ClusterService {
+// NewClusterService creates a new ClusterServiceImpl. clusterManager may be
+// nil, in which case ListClusters falls back to the database's last
+// persisted status for every cluster.
+func NewClusterService(clusterRepo repositories.ClusterRepository, clusterManager *ClusterManager, log *logrus.Logger) ClusterService {
 	if clusterRepo == nil {
 		log.Fatal("ClusterRepository is nil when creating ClusterService. This indicates a critical setup error.")
 	}
 	return &ClusterServiceImpl{
-		clusterRepo: clusterRepo,
-		log:         log,
+		clusterRepo:    clusterRepo,
+		clusterManager: clusterManager,
+		log:            log,
 	}
 }
 
 // RegisterCluster validates input and creates a new cluster record.
-// kubeconfigContent is the actual content read from the uploaded file.
-func (s *ClusterServiceImpl) RegisterCluster(ctx context.Context, name, kubeconfigContent string) (*models.Cluster, error) {
+// kubeconfigCiphertext is the envelope-encrypted kubeconfig produced by the
+// handler; the plaintext is never seen at this layer.
+func (s *ClusterServiceImpl) RegisterCluster(ctx context.Context, name string, kubeconfigCiphertext []byte, dekID string, probe *KubeconfigProbeResult, probeErr error, source types.ClusterSource) (*models.Cluster, error) {
 	s.log.WithContext(ctx).WithField("cluster_name", name).Info("Attempting to register new cluster with uploaded kubeconfig.")
 
-	// Input validation for name and kubeconfig content
-	if strings.TrimSpace(name) == "" || strings.TrimSpace(kubeconfigContent) == "" {
-		s.log.WithContext(ctx).Warn("Invalid cluster registration input: name or kubeconfig content is empty.")
+	// Input validation for name and kubeconfig ciphertext
+	if strings.TrimSpace(name) == "" || len(kubeconfigCiphertext) == 0 || strings.TrimSpace(dekID) == "" {
+		s.log.WithContext(ctx).Warn("Invalid cluster registration input: name or encrypted kubeconfig is empty.")
 		return nil, customerrors.NewCustomError(
 			customerrors.ErrCodeInvalidInput,
 
@@ -60,11 +80,31 @@ func (s *ClusterServiceImpl) RegisterCluster(ctx context.Context, name, kubeconf
 		)
 	}
 
-	// Create the domain model
+	if source == "" {
+		source = types.ClusterSourceKubeconfig
+	}
+
+	// Create the domain model, recording what the registration probe observed
+	// so ListClusters can surface connectivity status without re-probing.
 	cluster := &models.Cluster{
-		Name: name,
-		// Assign the content to the Kubeconfig field in the model
-		Kubeconfig: kubeconfigContent, // Assuming models.Cluster has Kubeconfig string
+		Name:                 name,
+		Source:               string(source),
+		KubeconfigCiphertext: kubeconfigCiphertext,
+		DEKID:                dekID,
+	}
+	if probe != nil {
+		cluster.ServerVersion = probe.ServerVersion
+		cluster.APIEndpoint = probe.APIEndpoint
+		cluster.LastHealthyAt = &probe.ProbedAt
+	}
+	if probeErr != nil {
+		// force=true registration despite a failed probe: record why, but
+		// leave ServerVersion/APIEndpoint/LastHealthyAt unset so
+		// GetClusterStatus/ListClusters correctly report it as unreachable
+		// until a later scheduler pass succeeds.
+		cluster.LastError = probeErr.Error()
+		s.log.WithContext(ctx).WithField("cluster_name", name).WithError(probeErr).
+			Warn("Registering cluster in a degraded state; its kubeconfig failed validation or reachability probing.")
 	}
 
 	if err := s.clusterRepo.Create(ctx, cluster); err != nil {
@@ -92,15 +132,62 @@ func (s *ClusterServiceImpl) ListClusters(ctx context.Context) ([]types.ClusterS
 	summaries := make([]types.ClusterSummary, len(clusters))
 	for i, cluster := range clusters {
 		summaries[i] = types.ClusterSummary{
+			ID:            cluster.ID.String(),
+			Name:          cluster.Name,
+			Source:        cluster.Source,
+			Reachable:     cluster.LastHealthyAt != nil && cluster.LastError == "",
+			ServerVersion: cluster.ServerVersion,
+			APIEndpoint:   cluster.APIEndpoint,
+			LastError:     cluster.LastError,
+			CreatedAt:     cluster.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:     cluster.UpdatedAt.Format(time.RFC3339),
+		}
+		if cluster.LastHealthyAt != nil {
+			summaries[i].LastHealthyAt = cluster.LastHealthyAt.Format(time.RFC3339)
+		}
 
-			ID:   cluster.ID.String(),
-			Name: cluster.Name,
-
-			CreatedAt: cluster.CreatedAt.Format(time.RFC3339),
-			UpdatedAt: cluster.UpdatedAt.Format(time.RFC3339),
+		if s.clusterManager != nil {
+			if live, ok := s.clusterManager.Get(cluster.ID); ok {
+				summaries[i].Reachable = live.Reachable
+				summaries[i].LastCheckedAt = live.LastCheckedAt.Format(time.RFC3339)
+				summaries[i].LatencyMS = live.LatencyMS
+				if live.ServerVersion != "" {
+					summaries[i].ServerVersion = live.ServerVersion
+				}
+				summaries[i].LastError = live.LastError
+			}
 		}
 	}
 
 	s.log.WithContext(ctx).Infof("Successfully retrieved %d clusters and converted to summaries.", len(summaries))
 	return summaries, nil
 }
+
+// GetClusterStatus retrieves a single cluster and converts it to the
+// ClusterStatus DTO surfaced by GET /api/v1/clusters/:id/status.
+func (s *ClusterServiceImpl) GetClusterStatus(ctx context.Context, id uuid.UUID) (*types.ClusterStatus, error) {
+	s.log.WithContext(ctx).WithField("cluster_id", id).Info("Attempting to retrieve cluster status.")
+
+	cluster, err := s.clusterRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &types.ClusterStatus{
+		ID:            cluster.ID.String(),
+		Name:          cluster.Name,
+		Reachable:     cluster.LastHealthyAt != nil && cluster.LastError == "",
+		ServerVersion: cluster.ServerVersion,
+		APIEndpoint:   cluster.APIEndpoint,
+		NodeCount:     cluster.NodeCount,
+		LastError:     cluster.LastError,
+	}
+	if cluster.LastSyncAt != nil {
+		status.LastSyncAt = cluster.LastSyncAt.Format(time.RFC3339)
+	}
+	if cluster.LastHealthyAt != nil {
+		status.LastHealthyAt = cluster.LastHealthyAt.Format(time.RFC3339)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClusterLiveStatus is a single cluster's most recently observed
+// reconciliation outcome, as last written by the scheduler (see
+// internal/app/scheduler). It is intentionally a small, in-memory-only
+// mirror of what the scheduler already persists via
+// repositories.ClusterStatusUpdate -- callers that just need "is it up right
+// now, and how fast did it answer" read it here instead of re-probing the
+// cluster's API server on every request.
+type ClusterLiveStatus struct {
+	Reachable     bool
+	ServerVersion string
+	LastError     string
+	LastCheckedAt time.Time
+	LatencyMS     int64
+}
+
+// ClusterManager is a process-wide, mutex-guarded cache of every registered
+// cluster's ClusterLiveStatus, keyed by cluster ID. It holds no clientsets
+// of its own -- kube.ClientCache already owns building and caching those --
+// ClusterManager exists purely so ListClusters and the health handlers can
+// read a cluster's last-observed status without either hitting its API
+// server themselves or round-tripping to the database.
+type ClusterManager struct {
+	mu       sync.RWMutex
+	statuses map[uuid.UUID]ClusterLiveStatus
+}
+
+// NewClusterManager creates an empty ClusterManager. Entries are populated
+// as the scheduler reconciles each cluster; a cluster has no entry until its
+// first reconciliation pass completes.
+func NewClusterManager() *ClusterManager {
+	return &ClusterManager{statuses: make(map[uuid.UUID]ClusterLiveStatus)}
+}
+
+// Update records clusterID's latest observed status, overwriting whatever
+// was cached for it before.
+func (m *ClusterManager) Update(clusterID uuid.UUID, status ClusterLiveStatus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statuses[clusterID] = status
+}
+
+// Get returns clusterID's cached status, and whether one has been recorded
+// yet at all.
+func (m *ClusterManager) Get(clusterID uuid.UUID) (ClusterLiveStatus, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	status, ok := m.statuses[clusterID]
+	return status, ok
+}
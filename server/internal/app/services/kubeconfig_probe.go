@@ -0,0 +1,128 @@
+/**
+ * @File: kubeconfig_probe.go
+ * @Title: Kubeconfig Validation and Liveness Probe
+ * @Description: Parses and reachability-tests a kubeconfig blob before Kylon
+ * @Description: trusts it, used at cluster registration time (upload,
+ * @Description: serviceaccount-token and in-cluster auto-registration).
+ * @Author: thesyscoder (github.com/thesyscoder)
+ */
+
+package services
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// probeTimeout bounds how long a single registration/health probe may take
+// against a candidate cluster before it is considered unreachable.
+const probeTimeout = 5 * time.Second
+
+// KubeconfigProbeResult captures what was observed about a cluster during a
+// successful probe.
+type KubeconfigProbeResult struct {
+	ServerVersion string
+	APIEndpoint   string
+	ProbedAt      time.Time
+}
+
+// ProbeKubeconfig parses kubeconfig bytes, requires a non-empty current
+// context and at least one cluster entry, builds a Clientset from it, and
+// performs a short-timeout Discovery().ServerVersion() plus a
+// CoreV1().Namespaces().List(limit=1) to confirm both reachability and that
+// the credentials carry enough RBAC to be useful.
+//
+// Failures are classified into typed customerrors.CustomError values so
+// callers (and the API clients reading their responses) can tell a malformed
+// kubeconfig apart from an unreachable cluster or insufficient permissions.
+func ProbeKubeconfig(ctx context.Context, kubeconfig []byte) (*KubeconfigProbeResult, error) {
+	rawConfig, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeInvalidInput,
+			"Kubeconfig could not be parsed.",
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+	}
+	if rawConfig.CurrentContext == "" {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeInvalidInput,
+			"Kubeconfig has no current-context set.",
+			nil,
+			http.StatusBadRequest,
+			nil,
+		)
+	}
+	if len(rawConfig.Clusters) == 0 {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeInvalidInput,
+			"Kubeconfig does not define any cluster entries.",
+			nil,
+			http.StatusBadRequest,
+			nil,
+		)
+	}
+
+	restConfig, err := clientcmd.NewDefaultClientConfig(*rawConfig, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeInvalidInput,
+			"Kubeconfig could not be resolved into a REST config.",
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+	}
+	restConfig.Timeout = probeTimeout
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeInvalidInput,
+			"Failed to build a Kubernetes clientset from the kubeconfig.",
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	version, err := clientset.Discovery().ServerVersion()
+	if err != nil {
+		return nil, classifyProbeError("Failed to reach the cluster's API server.", err)
+	}
+
+	if _, err := clientset.CoreV1().Namespaces().List(probeCtx, metav1.ListOptions{Limit: 1}); err != nil {
+		return nil, classifyProbeError("Kubeconfig is reachable but lacks permission to list namespaces.", err)
+	}
+
+	return &KubeconfigProbeResult{
+		ServerVersion: version.String(),
+		APIEndpoint:   restConfig.Host,
+		ProbedAt:      time.Now(),
+	}, nil
+}
+
+// classifyProbeError maps a client-go/Kubernetes API error onto the
+// customerrors code and HTTP status that best describes it.
+func classifyProbeError(message string, err error) error {
+	switch {
+	case k8serrors.IsUnauthorized(err):
+		return customerrors.NewCustomError(customerrors.ErrCodeUnauthorized, message, err, http.StatusUnauthorized, nil)
+	case k8serrors.IsForbidden(err):
+		return customerrors.NewCustomError(customerrors.ErrCodePermissionDenied, message, err, http.StatusForbidden, nil)
+	default:
+		return customerrors.NewCustomError(customerrors.ErrCodeClusterUnreachable, message, err, http.StatusUnprocessableEntity, nil)
+	}
+}
@@ -0,0 +1,115 @@
+/**
+ * @File: apply.go
+ * @Title: Tracked Resource Apply Path
+ * @Description: The only place kylon applies arbitrary resources to a
+ * @Description: registered cluster on an app's behalf; stamps every one with
+ * @Description: InjectInstanceLabel before applying, then hands the result to
+ * @Description: EnsureBundle so the reconciler and GetBundleStatus have
+ * @Description: something to report on.
+ * @Author: thesyscoder (github.com/thesyscoder)
+ */
+
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+)
+
+// ApplyBundle creates or updates each of manifests on clusterID -- in
+// namespace, unless a manifest already names its own -- stamping
+// InjectInstanceLabel onto every one first so the reconciler can discover
+// them later via a label selector. It then calls EnsureBundle with the
+// resulting TrackedResourceRefs, recording what was applied and starting the
+// cluster's reconciler if it isn't already running. A failure partway
+// through leaves whatever was already applied in place; the caller is
+// expected to retry with the same manifests, which is safe since every apply
+// here is itself idempotent (create-if-absent, update-in-place).
+func (t *Tracker) ApplyBundle(ctx context.Context, clusterID uuid.UUID, appName, namespace string, manifests []*unstructured.Unstructured) ([]TrackedResourceRef, error) {
+	client, err := t.dynamicClientFor(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	mapper, err := t.restMapperFor(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]TrackedResourceRef, 0, len(manifests))
+	for _, manifest := range manifests {
+		resourceNamespace := manifest.GetNamespace()
+		if resourceNamespace == "" {
+			resourceNamespace = namespace
+			manifest.SetNamespace(resourceNamespace)
+		}
+		InjectInstanceLabel(manifest, clusterID, appName)
+
+		gvk := manifest.GroupVersionKind()
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return nil, customerrors.NewCustomError(
+				customerrors.ErrCodeStatusCRApplyFailed,
+				fmt.Sprintf("Failed to resolve resource type for '%s'.", gvk.String()),
+				err,
+				http.StatusBadRequest,
+				nil,
+			)
+		}
+		gvr := mapping.Resource
+
+		resourceClient := client.Resource(gvr).Namespace(resourceNamespace)
+		if _, err := resourceClient.Get(ctx, manifest.GetName(), metav1.GetOptions{}); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, customerrors.NewCustomError(
+					customerrors.ErrCodeStatusCRApplyFailed,
+					fmt.Sprintf("Failed to read existing '%s/%s'.", resourceNamespace, manifest.GetName()),
+					err,
+					http.StatusInternalServerError,
+					nil,
+				)
+			}
+			if _, err := resourceClient.Create(ctx, manifest, metav1.CreateOptions{}); err != nil {
+				return nil, customerrors.NewCustomError(
+					customerrors.ErrCodeStatusCRApplyFailed,
+					fmt.Sprintf("Failed to create '%s/%s'.", resourceNamespace, manifest.GetName()),
+					err,
+					http.StatusInternalServerError,
+					nil,
+				)
+			}
+		} else if _, err := resourceClient.Update(ctx, manifest, metav1.UpdateOptions{}); err != nil {
+			return nil, customerrors.NewCustomError(
+				customerrors.ErrCodeStatusCRApplyFailed,
+				fmt.Sprintf("Failed to update '%s/%s'.", resourceNamespace, manifest.GetName()),
+				err,
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+
+		refs = append(refs, TrackedResourceRef{
+			Group:     gvr.Group,
+			Version:   gvr.Version,
+			Resource:  gvr.Resource,
+			Kind:      gvk.Kind,
+			Name:      manifest.GetName(),
+			Namespace: resourceNamespace,
+		})
+	}
+
+	if err := t.EnsureBundle(ctx, clusterID, appName, namespace, refs); err != nil {
+		return nil, err
+	}
+
+	t.log.WithField("cluster_id", clusterID).WithField("app", appName).Infof("Applied %d resources for app bundle.", len(refs))
+	return refs, nil
+}
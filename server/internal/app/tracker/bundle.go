@@ -0,0 +1,262 @@
+/**
+ * @File: bundle.go
+ * @Title: ResourceBundleState CR Lifecycle
+ * @Description: Idempotent creation, status retrieval and deletion of a
+ * @Description: ResourceBundleState CR for one app/cluster pair, plus teardown
+ * @Description: of the resources it tracks.
+ * @Author: thesyscoder (github.com/thesyscoder)
+ */
+
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+)
+
+// EnsureBundle idempotently creates or updates the ResourceBundleState CR
+// for (clusterID, appName), recording every tracked resource's GVR+name so
+// the cluster's reconciler knows what it is reporting on, then makes sure
+// that cluster's reconciler goroutine is running.
+func (t *Tracker) EnsureBundle(ctx context.Context, clusterID uuid.UUID, appName, namespace string, tracked []TrackedResourceRef) error {
+	client, err := t.dynamicClientFor(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	name := bundleName(appName)
+
+	existing, err := client.Resource(ResourceBundleStateGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return customerrors.NewCustomError(
+			customerrors.ErrCodeStatusCRApplyFailed,
+			fmt.Sprintf("Failed to read ResourceBundleState '%s/%s'.", namespace, name),
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	bundle := newResourceBundleState(name, namespace, appName, clusterID, tracked)
+
+	if apierrors.IsNotFound(err) {
+		if _, err := client.Resource(ResourceBundleStateGVR).Namespace(namespace).Create(ctx, bundle, metav1.CreateOptions{}); err != nil {
+			return customerrors.NewCustomError(
+				customerrors.ErrCodeStatusCRApplyFailed,
+				fmt.Sprintf("Failed to create ResourceBundleState '%s/%s'.", namespace, name),
+				err,
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		t.log.WithField("cluster_id", clusterID).WithField("app", appName).Info("Created ResourceBundleState CR.")
+	} else {
+		bundle.SetResourceVersion(existing.GetResourceVersion())
+		if _, err := client.Resource(ResourceBundleStateGVR).Namespace(namespace).Update(ctx, bundle, metav1.UpdateOptions{}); err != nil {
+			return customerrors.NewCustomError(
+				customerrors.ErrCodeStatusCRApplyFailed,
+				fmt.Sprintf("Failed to update ResourceBundleState '%s/%s'.", namespace, name),
+				err,
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		t.log.WithField("cluster_id", clusterID).WithField("app", appName).Info("Updated ResourceBundleState CR.")
+	}
+
+	return t.EnsureReconciler(ctx, clusterID)
+}
+
+// DeleteBundle tears down every resource tracked by (clusterID, appName)'s
+// ResourceBundleState, then the CR itself, so deleting an app leaves nothing
+// orphaned on the cluster. It is idempotent: deleting an already-absent
+// bundle is not an error.
+func (t *Tracker) DeleteBundle(ctx context.Context, clusterID uuid.UUID, appName, namespace string) error {
+	client, err := t.dynamicClientFor(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	name := bundleName(appName)
+
+	bundle, err := client.Resource(ResourceBundleStateGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return customerrors.NewCustomError(
+			customerrors.ErrCodeStatusCRApplyFailed,
+			fmt.Sprintf("Failed to read ResourceBundleState '%s/%s' for deletion.", namespace, name),
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	for _, ref := range trackedResourcesOf(bundle) {
+		resourceNamespace := ref.Namespace
+		if resourceNamespace == "" {
+			resourceNamespace = namespace
+		}
+		if err := client.Resource(ref.groupVersionResource()).Namespace(resourceNamespace).Delete(ctx, ref.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			t.log.WithField("cluster_id", clusterID).WithField("app", appName).WithField("resource", ref.Name).WithError(err).Warn("Failed to delete tracked resource while tearing down bundle; continuing.")
+		}
+	}
+
+	if err := client.Resource(ResourceBundleStateGVR).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return customerrors.NewCustomError(
+			customerrors.ErrCodeStatusCRApplyFailed,
+			fmt.Sprintf("Failed to delete ResourceBundleState '%s/%s'.", namespace, name),
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	t.log.WithField("cluster_id", clusterID).WithField("app", appName).Info("Deleted ResourceBundleState CR and its tracked resources.")
+	return nil
+}
+
+// Status returns appName's last-reconciled BundleStatus on clusterID, read
+// directly from its ResourceBundleState CR's status subresource.
+func (t *Tracker) Status(ctx context.Context, clusterID uuid.UUID, appName, namespace string) (*BundleStatus, error) {
+	client, err := t.dynamicClientFor(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := bundleName(appName)
+	bundle, err := client.Resource(ResourceBundleStateGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, customerrors.NewCustomError(
+				customerrors.ErrCodeResourceNotFound,
+				fmt.Sprintf("No ResourceBundleState found for app '%s' on this cluster.", appName),
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		}
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeTrackerReconcileFailed,
+			fmt.Sprintf("Failed to read ResourceBundleState '%s/%s'.", namespace, name),
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	return bundleStatusOf(clusterID, appName, bundle), nil
+}
+
+// newResourceBundleState builds the unstructured ResourceBundleState object
+// for (clusterID, appName), recording tracked as its spec.trackedResources.
+func newResourceBundleState(name, namespace, appName string, clusterID uuid.UUID, tracked []TrackedResourceRef) *unstructured.Unstructured {
+	trackedResources := make([]interface{}, 0, len(tracked))
+	for _, ref := range tracked {
+		trackedResources = append(trackedResources, map[string]interface{}{
+			"group":     ref.Group,
+			"version":   ref.Version,
+			"resource":  ref.Resource,
+			"kind":      ref.Kind,
+			"name":      ref.Name,
+			"namespace": ref.Namespace,
+		})
+	}
+
+	bundle := &unstructured.Unstructured{}
+	bundle.SetAPIVersion(resourceBundleStateAPIVersion)
+	bundle.SetKind(resourceBundleStateKind)
+	bundle.SetName(name)
+	bundle.SetNamespace(namespace)
+	InjectInstanceLabel(bundle, clusterID, appName)
+
+	bundle.Object["spec"] = map[string]interface{}{
+		"appName":          appName,
+		"clusterId":        clusterID.String(),
+		"trackedResources": trackedResources,
+	}
+
+	return bundle
+}
+
+// trackedResourcesOf reads bundle's spec.trackedResources back into
+// TrackedResourceRefs, for DeleteBundle's teardown pass.
+func trackedResourcesOf(bundle *unstructured.Unstructured) []TrackedResourceRef {
+	raw, found, err := unstructured.NestedSlice(bundle.Object, "spec", "trackedResources")
+	if err != nil || !found {
+		return nil
+	}
+
+	refs := make([]TrackedResourceRef, 0, len(raw))
+	for _, item := range raw {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		refs = append(refs, TrackedResourceRef{
+			Group:     stringField(entry, "group"),
+			Version:   stringField(entry, "version"),
+			Resource:  stringField(entry, "resource"),
+			Kind:      stringField(entry, "kind"),
+			Name:      stringField(entry, "name"),
+			Namespace: stringField(entry, "namespace"),
+		})
+	}
+	return refs
+}
+
+// bundleStatusOf reads bundle's status subresource into a BundleStatus.
+func bundleStatusOf(clusterID uuid.UUID, appName string, bundle *unstructured.Unstructured) *BundleStatus {
+	status := &BundleStatus{AppName: appName, ClusterID: clusterID.String()}
+
+	readyCount, _, _ := unstructured.NestedInt64(bundle.Object, "status", "readyCount")
+	availableCount, _, _ := unstructured.NestedInt64(bundle.Object, "status", "availableCount")
+	failedCount, _, _ := unstructured.NestedInt64(bundle.Object, "status", "failedCount")
+	observedAt, _, _ := unstructured.NestedString(bundle.Object, "status", "observedAt")
+	endpoints, _, _ := unstructured.NestedStringSlice(bundle.Object, "status", "serviceEndpoints")
+
+	status.ReadyCount = int(readyCount)
+	status.AvailableCount = int(availableCount)
+	status.FailedCount = int(failedCount)
+	status.ObservedAt = observedAt
+	status.ServiceEndpoints = endpoints
+
+	if phases, found, _ := unstructured.NestedMap(bundle.Object, "status", "podPhases"); found {
+		podPhases := make(map[string]int, len(phases))
+		for phase, count := range phases {
+			podPhases[phase] = toInt(count)
+		}
+		status.PodPhases = podPhases
+	}
+
+	return status
+}
+
+// stringField reads a string field from an unstructured map entry, returning
+// "" if it is absent or not a string.
+func stringField(entry map[string]interface{}, key string) string {
+	v, _ := entry[key].(string)
+	return v
+}
+
+// toInt converts an unstructured numeric value (int64 when set in-process,
+// float64 after a round trip through the API server's JSON encoding) to int.
+func toInt(v interface{}) int {
+	switch n := v.(type) {
+	case int64:
+		return int(n)
+	case float64:
+		return int(n)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,105 @@
+/**
+ * @File: crd.go
+ * @Title: ResourceBundleState CRD
+ * @Description: Defines the ResourceBundleState custom resource that tracks,
+ * @Description: per app/cluster pair, the set of resources kylon applied to a
+ * @Description: registered cluster and the reconciler's last-observed status
+ * @Description: for them -- the ONAP rsync pattern of a status CR per tracked
+ * @Description: application.
+ * @Author: thesyscoder (github.com/thesyscoder)
+ */
+
+package tracker
+
+import "k8s.io/apimachinery/pkg/runtime/schema"
+
+// ResourceBundleStateGVR identifies the ResourceBundleState custom resource
+// on a registered cluster.
+var ResourceBundleStateGVR = schema.GroupVersionResource{
+	Group:    "kylon.io",
+	Version:  "v1alpha1",
+	Resource: "resourcebundlestates",
+}
+
+// resourceBundleStateAPIVersion and resourceBundleStateKind are the CR's
+// apiVersion/kind, used when constructing unstructured ResourceBundleState
+// objects.
+const (
+	resourceBundleStateAPIVersion = "kylon.io/v1alpha1"
+	resourceBundleStateKind       = "ResourceBundleState"
+)
+
+// ResourceBundleStateCRD is the CustomResourceDefinition manifest for
+// ResourceBundleState. Its spec lists the GVR+name of every resource kylon
+// applied for one app; its status subresource carries the reconciler's
+// last-observed Ready/Available/Failed counts, pod phases and service
+// endpoints. Operators must apply this manifest to a cluster (e.g. via
+// `kubectl apply -f`) before kylon can create ResourceBundleState CRs on it
+// -- kylon itself never installs CRDs onto a registered cluster.
+const ResourceBundleStateCRD = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: resourcebundlestates.kylon.io
+spec:
+  group: kylon.io
+  scope: Namespaced
+  names:
+    kind: ResourceBundleState
+    listKind: ResourceBundleStateList
+    plural: resourcebundlestates
+    singular: resourcebundlestate
+  versions:
+    - name: v1alpha1
+      served: true
+      storage: true
+      subresources:
+        status: {}
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              properties:
+                appName:
+                  type: string
+                clusterId:
+                  type: string
+                trackedResources:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      group:
+                        type: string
+                      version:
+                        type: string
+                      resource:
+                        type: string
+                      kind:
+                        type: string
+                      name:
+                        type: string
+                      namespace:
+                        type: string
+            status:
+              type: object
+              properties:
+                readyCount:
+                  type: integer
+                availableCount:
+                  type: integer
+                failedCount:
+                  type: integer
+                podPhases:
+                  type: object
+                  additionalProperties:
+                    type: integer
+                serviceEndpoints:
+                  type: array
+                  items:
+                    type: string
+                observedAt:
+                  type: string
+`
@@ -0,0 +1,42 @@
+/**
+ * @File: labels.go
+ * @Title: Resource-Tracking Label Injection
+ * @Description: Stamps the canonical kylon.io/instance-id label onto every
+ * @Description: resource kylon applies to a registered cluster, so the
+ * @Description: reconciler can discover everything belonging to one
+ * @Description: app/cluster pair via a label-selector informer instead of
+ * @Description: polling or relying on owner references across kinds.
+ * @Author: thesyscoder (github.com/thesyscoder)
+ */
+
+package tracker
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// InstanceLabelKey is the label kylon injects onto every resource it applies
+// to a registered cluster, identifying the (cluster, app) pair it belongs to.
+const InstanceLabelKey = "kylon.io/instance-id"
+
+// InstanceID derives the canonical kylon.io/instance-id label value for a
+// (clusterID, appName) pair. Kept as one function so bundle creation, label
+// injection and the reconciler's grouping logic can never drift out of sync.
+func InstanceID(clusterID uuid.UUID, appName string) string {
+	return fmt.Sprintf("%s.%s", clusterID, appName)
+}
+
+// InjectInstanceLabel stamps the kylon.io/instance-id label for
+// (clusterID, appName) onto obj, creating its labels map if the manifest
+// didn't already have one.
+func InjectInstanceLabel(obj *unstructured.Unstructured, clusterID uuid.UUID, appName string) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string, 1)
+	}
+	labels[InstanceLabelKey] = InstanceID(clusterID, appName)
+	obj.SetLabels(labels)
+}
@@ -0,0 +1,328 @@
+/**
+ * @File: reconciler.go
+ * @Title: Per-Cluster Resource-Tracking Reconciler
+ * @Description: Watches every pod and service labeled with
+ * @Description: kylon.io/instance-id on a registered cluster via
+ * @Description: label-selector informers -- never polling -- and keeps each
+ * @Description: labeled app's ResourceBundleState CR status subresource
+ * @Description: current with observed conditions.
+ * @Author: thesyscoder/kylon (github.com/thesyscoder)
+ */
+
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+)
+
+// podsGVR and servicesGVR are the GroupVersionResources the reconciler's
+// informers watch: pod phases are what the status conditions
+// (Ready/Available/Failed) are derived from, and services are what
+// status.serviceEndpoints is derived from.
+var (
+	podsGVR     = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	servicesGVR = schema.GroupVersionResource{Version: "v1", Resource: "services"}
+)
+
+// reconcilerResyncPeriod is how often the informer replays its full cache as
+// a safety net against missed watch events; the label-selector watch itself
+// is the reconciler's only source of real-time updates.
+const reconcilerResyncPeriod = 10 * time.Minute
+
+// EnsureReconciler starts (if not already running) the reconciler goroutine
+// for clusterID, watching every pod and service labeled with kylon.io/instance-id
+// and keeping every labeled app's ResourceBundleState CR status current. It
+// is safe to call repeatedly; an already-running reconciler is left
+// untouched, so EnsureBundle can call it on every bundle creation/update.
+func (t *Tracker) EnsureReconciler(ctx context.Context, clusterID uuid.UUID) error {
+	t.mu.Lock()
+	if _, ok := t.reconcilers[clusterID]; ok {
+		t.mu.Unlock()
+		return nil
+	}
+	t.mu.Unlock()
+
+	client, err := t.dynamicClientFor(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	reconcilerCtx, cancel := context.WithCancel(context.Background())
+
+	t.mu.Lock()
+	if _, ok := t.reconcilers[clusterID]; ok {
+		t.mu.Unlock()
+		cancel()
+		return nil
+	}
+	t.reconcilers[clusterID] = cancel
+	t.mu.Unlock()
+
+	go t.runReconciler(reconcilerCtx, clusterID, client)
+
+	t.log.WithField("cluster_id", clusterID).Info("Started resource-tracking reconciler.")
+	return nil
+}
+
+// StopReconciler stops and forgets clusterID's reconciler, if one is
+// running.
+func (t *Tracker) StopReconciler(clusterID uuid.UUID) {
+	t.mu.Lock()
+	cancel, ok := t.reconcilers[clusterID]
+	if ok {
+		delete(t.reconcilers, clusterID)
+	}
+	t.mu.Unlock()
+
+	if ok {
+		cancel()
+	}
+}
+
+// runReconciler watches every pod and service labeled with
+// kylon.io/instance-id via dynamic, label-selector-filtered informers and, on
+// every add/update/delete event, recomputes and pushes a fresh BundleStatus
+// for each distinct app the informers' caches currently know about, until ctx
+// is cancelled.
+func (t *Tracker) runReconciler(ctx context.Context, clusterID uuid.UUID, client dynamic.Interface) {
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, reconcilerResyncPeriod, metav1.NamespaceAll, func(opts *metav1.ListOptions) {
+		opts.LabelSelector = InstanceLabelKey
+	})
+	podInformer := factory.ForResource(podsGVR).Informer()
+	serviceInformer := factory.ForResource(servicesGVR).Informer()
+
+	reconcileAll := func(interface{}) {
+		t.reconcileAllBundles(ctx, clusterID, client, podInformer.GetStore(), serviceInformer.GetStore())
+	}
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc: reconcileAll,
+		UpdateFunc: func(_, obj interface{}) {
+			reconcileAll(obj)
+		},
+		DeleteFunc: reconcileAll,
+	}
+	podInformer.AddEventHandler(handler)
+	serviceInformer.AddEventHandler(handler)
+
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+
+	<-ctx.Done()
+	t.log.WithField("cluster_id", clusterID).Info("Stopped resource-tracking reconciler.")
+}
+
+// reconcileAllBundles groups the informers' cached pods and services by the
+// app each is labeled with, computes each app's BundleStatus, and pushes it
+// to that app's ResourceBundleState CR status subresource.
+func (t *Tracker) reconcileAllBundles(ctx context.Context, clusterID uuid.UUID, client dynamic.Interface, podStore, serviceStore cache.Store) {
+	podsByApp := make(map[string][]*corev1.Pod)
+	servicesByApp := make(map[string][]*corev1.Service)
+	namespaceOf := make(map[string]string)
+
+	for _, obj := range podStore.List() {
+		unstructuredPod, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+
+		instanceID, ok := unstructuredPod.GetLabels()[InstanceLabelKey]
+		if !ok {
+			continue
+		}
+		appName, ok := appNameFromInstanceID(clusterID, instanceID)
+		if !ok {
+			continue
+		}
+
+		pod := &corev1.Pod{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredPod.Object, pod); err != nil {
+			t.log.WithField("cluster_id", clusterID).WithError(err).Warn("Failed to convert labeled pod for reconciliation; skipping it.")
+			continue
+		}
+
+		podsByApp[appName] = append(podsByApp[appName], pod)
+		namespaceOf[appName] = pod.Namespace
+	}
+
+	for _, obj := range serviceStore.List() {
+		unstructuredSvc, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+
+		instanceID, ok := unstructuredSvc.GetLabels()[InstanceLabelKey]
+		if !ok {
+			continue
+		}
+		appName, ok := appNameFromInstanceID(clusterID, instanceID)
+		if !ok {
+			continue
+		}
+
+		svc := &corev1.Service{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredSvc.Object, svc); err != nil {
+			t.log.WithField("cluster_id", clusterID).WithError(err).Warn("Failed to convert labeled service for reconciliation; skipping it.")
+			continue
+		}
+
+		servicesByApp[appName] = append(servicesByApp[appName], svc)
+		namespaceOf[appName] = svc.Namespace
+	}
+
+	apps := make(map[string]struct{}, len(podsByApp)+len(servicesByApp))
+	for appName := range podsByApp {
+		apps[appName] = struct{}{}
+	}
+	for appName := range servicesByApp {
+		apps[appName] = struct{}{}
+	}
+
+	for appName := range apps {
+		status := computeBundleStatus(clusterID, appName, podsByApp[appName])
+		status.ServiceEndpoints = computeServiceEndpoints(servicesByApp[appName])
+		if err := t.applyBundleStatus(ctx, client, namespaceOf[appName], status); err != nil {
+			t.log.WithField("cluster_id", clusterID).WithField("app", appName).WithError(err).Error("Failed to update ResourceBundleState status.")
+		}
+	}
+}
+
+// appNameFromInstanceID recovers the app name from an
+// InstanceID(clusterID, appName) value, verifying it belongs to clusterID.
+func appNameFromInstanceID(clusterID uuid.UUID, instanceID string) (string, bool) {
+	prefix := clusterID.String() + "."
+	if !strings.HasPrefix(instanceID, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(instanceID, prefix), true
+}
+
+// computeBundleStatus tallies pod phases into a BundleStatus: Ready counts
+// running pods with every container ready, Available counts running pods
+// regardless of container readiness, and Failed counts pods in the Failed
+// phase.
+func computeBundleStatus(clusterID uuid.UUID, appName string, pods []*corev1.Pod) *BundleStatus {
+	status := &BundleStatus{
+		AppName:    appName,
+		ClusterID:  clusterID.String(),
+		PodPhases:  make(map[string]int, len(pods)),
+		ObservedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	for _, pod := range pods {
+		status.PodPhases[string(pod.Status.Phase)]++
+
+		switch pod.Status.Phase {
+		case corev1.PodRunning:
+			status.AvailableCount++
+			if allContainersReady(pod) {
+				status.ReadyCount++
+			}
+		case corev1.PodFailed:
+			status.FailedCount++
+		}
+	}
+
+	return status
+}
+
+// computeServiceEndpoints returns "clusterIP:port" for every port of every
+// given service, sorted for stable output across reconcile passes. A service
+// with no ClusterIP yet (e.g. still provisioning, or headless) is skipped,
+// since there is nothing reachable to report yet.
+func computeServiceEndpoints(services []*corev1.Service) []string {
+	endpoints := make([]string, 0, len(services))
+	for _, svc := range services {
+		if svc.Spec.ClusterIP == "" || svc.Spec.ClusterIP == corev1.ClusterIPNone {
+			continue
+		}
+		for _, port := range svc.Spec.Ports {
+			endpoints = append(endpoints, fmt.Sprintf("%s:%d", svc.Spec.ClusterIP, port.Port))
+		}
+	}
+	sort.Strings(endpoints)
+	return endpoints
+}
+
+// allContainersReady reports whether every container in pod's status is
+// ready.
+func allContainersReady(pod *corev1.Pod) bool {
+	if len(pod.Status.ContainerStatuses) == 0 {
+		return false
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if !cs.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+// applyBundleStatus patches status's fields onto its ResourceBundleState
+// CR's status subresource.
+func (t *Tracker) applyBundleStatus(ctx context.Context, client dynamic.Interface, namespace string, status *BundleStatus) error {
+	name := bundleName(status.AppName)
+
+	bundle, err := client.Resource(ResourceBundleStateGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// The bundle's CR hasn't been created yet (or was already deleted);
+			// nothing to update.
+			return nil
+		}
+		return customerrors.NewCustomError(
+			customerrors.ErrCodeTrackerReconcileFailed,
+			fmt.Sprintf("Failed to read ResourceBundleState '%s/%s' to update its status.", namespace, name),
+			err,
+			0,
+			nil,
+		)
+	}
+
+	podPhases := make(map[string]interface{}, len(status.PodPhases))
+	for phase, count := range status.PodPhases {
+		podPhases[phase] = int64(count)
+	}
+
+	serviceEndpoints := make([]interface{}, 0, len(status.ServiceEndpoints))
+	for _, endpoint := range status.ServiceEndpoints {
+		serviceEndpoints = append(serviceEndpoints, endpoint)
+	}
+
+	bundle.Object["status"] = map[string]interface{}{
+		"readyCount":       int64(status.ReadyCount),
+		"availableCount":   int64(status.AvailableCount),
+		"failedCount":      int64(status.FailedCount),
+		"podPhases":        podPhases,
+		"serviceEndpoints": serviceEndpoints,
+		"observedAt":       status.ObservedAt,
+	}
+
+	if _, err := client.Resource(ResourceBundleStateGVR).Namespace(namespace).UpdateStatus(ctx, bundle, metav1.UpdateOptions{}); err != nil {
+		return customerrors.NewCustomError(
+			customerrors.ErrCodeTrackerReconcileFailed,
+			fmt.Sprintf("Failed to update ResourceBundleState '%s/%s' status.", namespace, name),
+			err,
+			0,
+			nil,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+/**
+ * @File: tracker.go
+ * @Title: Resource-Tracking Tracker
+ * @Description: Owns, per registered cluster, a cached dynamic client and a
+ * @Description: reconciler goroutine -- mirroring kube.ClientCache's
+ * @Description: lazily-built, cluster-keyed cache -- so the rest of the
+ * @Description: application never builds a dynamic client or starts a
+ * @Description: reconciler itself.
+ * @Author: thesyscoder (github.com/thesyscoder)
+ */
+
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	kube "github.com/thesyscoder/kylon/internal/infrastructure/kubernetes"
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+// TrackedResourceRef identifies a single resource kylon applied to a
+// cluster as part of one app's bundle, by its GVR (needed to address it
+// through a dynamic client) plus kind, name and namespace for display and
+// targeted deletion.
+type TrackedResourceRef struct {
+	Group     string `json:"group"`
+	Version   string `json:"version"`
+	Resource  string `json:"resource"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// groupVersionResource returns r's schema.GroupVersionResource, for
+// addressing it through a dynamic client.
+func (r TrackedResourceRef) groupVersionResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: r.Group, Version: r.Version, Resource: r.Resource}
+}
+
+// BundleStatus is the reconciler's last-observed condition of one app's
+// tracked resources on one cluster, as surfaced by
+// GET /api/v1/clusters/:id/bundles/:app/status.
+type BundleStatus struct {
+	AppName          string         `json:"app_name"`
+	ClusterID        string         `json:"cluster_id"`
+	ReadyCount       int            `json:"ready_count"`
+	AvailableCount   int            `json:"available_count"`
+	FailedCount      int            `json:"failed_count"`
+	PodPhases        map[string]int `json:"pod_phases,omitempty"`
+	ServiceEndpoints []string       `json:"service_endpoints,omitempty"`
+	ObservedAt       string         `json:"observed_at,omitempty"`
+}
+
+// Tracker manages ResourceBundleState CRs and the per-cluster reconciler
+// that keeps their status subresources current. Like kube.ClientCache, it
+// lazily builds and caches what each cluster needs (here, a dynamic client
+// and a reconciler goroutine) keyed by cluster UUID.
+type Tracker struct {
+	clientCache *kube.ClientCache
+	log         *logrus.Logger
+
+	mu             sync.Mutex
+	dynamicClients map[uuid.UUID]dynamic.Interface
+	restMappers    map[uuid.UUID]*restmapper.DeferredDiscoveryRESTMapper
+	reconcilers    map[uuid.UUID]context.CancelFunc
+}
+
+// NewTracker creates a Tracker backed by the given ClientCache, from which it
+// resolves each cluster's rest.Config to build dynamic clients.
+func NewTracker(clientCache *kube.ClientCache, log *logrus.Logger) *Tracker {
+	if clientCache == nil {
+		log.Fatal("ClientCache is nil when creating Tracker. Critical setup error.")
+	}
+	return &Tracker{
+		clientCache:    clientCache,
+		log:            log,
+		dynamicClients: make(map[uuid.UUID]dynamic.Interface),
+		restMappers:    make(map[uuid.UUID]*restmapper.DeferredDiscoveryRESTMapper),
+		reconcilers:    make(map[uuid.UUID]context.CancelFunc),
+	}
+}
+
+// dynamicClientFor returns a cached dynamic.Interface for clusterID, building
+// one from the cluster's decrypted kubeconfig on first use -- the same
+// rest.Config ClientCache resolves for its typed Clientset.
+func (t *Tracker) dynamicClientFor(ctx context.Context, clusterID uuid.UUID) (dynamic.Interface, error) {
+	t.mu.Lock()
+	if client, ok := t.dynamicClients[clusterID]; ok {
+		t.mu.Unlock()
+		return client, nil
+	}
+	t.mu.Unlock()
+
+	restConfig, err := t.clientCache.RESTConfigFor(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeTrackerReconcileFailed,
+			fmt.Sprintf("Failed to build dynamic client for cluster '%s'.", clusterID),
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	t.mu.Lock()
+	t.dynamicClients[clusterID] = client
+	t.mu.Unlock()
+
+	return client, nil
+}
+
+// restMapperFor returns a cached discovery-backed RESTMapper for clusterID,
+// building one from the cluster's cached Clientset's discovery client on
+// first use -- mirroring dynamicClientFor's lazily-built, cluster-keyed
+// cache -- so ApplyBundle can resolve an arbitrary manifest's
+// apiVersion/kind to the GroupVersionResource its dynamic client needs.
+func (t *Tracker) restMapperFor(ctx context.Context, clusterID uuid.UUID) (*restmapper.DeferredDiscoveryRESTMapper, error) {
+	t.mu.Lock()
+	if mapper, ok := t.restMappers[clusterID]; ok {
+		t.mu.Unlock()
+		return mapper, nil
+	}
+	t.mu.Unlock()
+
+	clientset, err := t.clientCache.ClientFor(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(clientset.Discovery()))
+
+	t.mu.Lock()
+	t.restMappers[clusterID] = mapper
+	t.mu.Unlock()
+
+	return mapper, nil
+}
+
+// bundleName derives the ResourceBundleState CR's name from appName. CR
+// names must be valid DNS subdomains; appName is expected to already be one
+// (the same name used to derive the instance ID), so this is an identity
+// function kept as a named conversion point in case that changes.
+func bundleName(appName string) string {
+	return appName
+}
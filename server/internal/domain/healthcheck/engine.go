@@ -0,0 +1,448 @@
+/**
+ * @File: engine.go
+ * @Title: Healthcheck Engine
+ * @Description: Runs a Spec's resource probes and test hooks against a
+ * @Description: registered cluster in a background goroutine, persisting
+ * @Description: incremental progress via HealthcheckRepository, modeled on
+ * @Description: ONAP multicloud-k8s's instance-healthcheck API. Mirrors
+ * @Description: kube.ClientCache and tracker.Tracker's pattern of a
+ * @Description: mutex-guarded map keyed by run ID holding what that run
+ * @Description: needs -- here, its cancellation func.
+ * @Author: thesyscoder/kylon (github.com/thesyscoder)
+ */
+
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/thesyscoder/kylon/internal/domain/models"
+	kube "github.com/thesyscoder/kylon/internal/infrastructure/kubernetes"
+	"github.com/thesyscoder/kylon/internal/infrastructure/repositories"
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// hookPollInterval and hookTimeout bound how a test hook pod is awaited:
+// polled for completion every hookPollInterval, up to hookTimeout total.
+const (
+	hookPollInterval = 2 * time.Second
+	hookTimeout      = 5 * time.Minute
+)
+
+// View is the GET-able aggregate state of a single healthcheck run.
+type View struct {
+	ID        string           `json:"id"`
+	ClusterID string           `json:"cluster_id"`
+	Status    string           `json:"status"`
+	Resources []ResourceResult `json:"resources,omitempty"`
+	Hooks     []HookResult     `json:"hooks,omitempty"`
+	Error     string           `json:"error,omitempty"`
+	CreatedAt string           `json:"created_at"`
+	UpdatedAt string           `json:"updated_at"`
+}
+
+// Engine owns the in-flight goroutine and cancellation func for every
+// currently-running healthcheck, keyed by run ID. Like tracker.Tracker, it
+// is a process-wide singleton the caller constructs once; a process restart
+// loses the ability to Cancel a run that was already in flight, but its
+// persisted results survive via HealthcheckRepository.
+type Engine struct {
+	clientCache *kube.ClientCache
+	repo        repositories.HealthcheckRepository
+	log         *logrus.Logger
+
+	mu      sync.Mutex
+	cancels map[uuid.UUID]context.CancelFunc
+}
+
+// NewEngine creates an Engine backed by the given ClientCache and repository.
+func NewEngine(clientCache *kube.ClientCache, repo repositories.HealthcheckRepository, log *logrus.Logger) *Engine {
+	if clientCache == nil || repo == nil {
+		log.Fatal("ClientCache or HealthcheckRepository is nil when creating Engine. Critical setup error.")
+	}
+	return &Engine{
+		clientCache: clientCache,
+		repo:        repo,
+		log:         log,
+		cancels:     make(map[uuid.UUID]context.CancelFunc),
+	}
+}
+
+// Start creates a RUNNING healthcheck record for clusterID and spec, then
+// runs its probes in a background goroutine detached from ctx (so the run
+// outlives the HTTP request that started it). It returns the record as soon
+// as it is persisted; callers poll Get for progress.
+func (e *Engine) Start(ctx context.Context, clusterID uuid.UUID, spec Spec) (*models.Healthcheck, error) {
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return nil, customerrors.NewCustomError(customerrors.ErrCodeInvalidInput, "Healthcheck spec could not be encoded.", err, http.StatusBadRequest, nil)
+	}
+
+	record := &models.Healthcheck{
+		ClusterID: clusterID,
+		Status:    StatusRunning,
+		SpecJSON:  specJSON,
+	}
+	if err := e.repo.Create(ctx, record); err != nil {
+		return nil, err
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	e.mu.Lock()
+	e.cancels[record.ID] = cancel
+	e.mu.Unlock()
+
+	go e.run(runCtx, record.ID, clusterID, spec)
+
+	return record, nil
+}
+
+// Get returns the current aggregate View of a run, decoding its
+// persisted Results as they stand (partial, while RUNNING; final otherwise).
+func (e *Engine) Get(ctx context.Context, id uuid.UUID) (*View, error) {
+	record, err := e.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &View{
+		ID:        record.ID.String(),
+		ClusterID: record.ClusterID.String(),
+		Status:    record.Status,
+		Error:     record.Error,
+		CreatedAt: record.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: record.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if len(record.ResultsJSON) > 0 {
+		var results Results
+		if err := json.Unmarshal(record.ResultsJSON, &results); err == nil {
+			view.Resources = results.Resources
+			view.Hooks = results.Hooks
+		}
+	}
+
+	return view, nil
+}
+
+// Cancel stops a RUNNING run (if this process is the one running it) and
+// deletes its record. Hook pod cleanup happens inside run itself once its
+// context is cancelled.
+func (e *Engine) Cancel(ctx context.Context, id uuid.UUID) error {
+	e.mu.Lock()
+	cancel, running := e.cancels[id]
+	e.mu.Unlock()
+
+	if running {
+		cancel()
+	}
+
+	return e.repo.Delete(ctx, id)
+}
+
+// run executes spec's resource probes and test hooks against clusterID,
+// persisting incremental progress, and always clears id from e.cancels on
+// return so a finished or cancelled run can no longer be Cancel'd.
+func (e *Engine) run(ctx context.Context, id, clusterID uuid.UUID, spec Spec) {
+	defer func() {
+		e.mu.Lock()
+		delete(e.cancels, id)
+		e.mu.Unlock()
+	}()
+
+	results := Results{}
+	persist := func(status string) {
+		resultsJSON, _ := json.Marshal(results)
+		// Runs outlive the HTTP request that started them, and may still be
+		// writing after Cancel's Delete -- use a
+		// fresh background context rather than the (possibly cancelled) run ctx.
+		if err := e.repo.UpdateResult(context.Background(), id, status, resultsJSON, ""); err != nil {
+			e.log.WithField("healthcheck_id", id).WithError(err).Warn("Failed to persist healthcheck progress.")
+		}
+	}
+
+	clientset, err := e.clientCache.ClientFor(ctx, clusterID)
+	if err != nil {
+		e.log.WithField("healthcheck_id", id).WithField("cluster_id", clusterID).WithError(err).Warn("Healthcheck could not resolve a client for its cluster.")
+		resultsJSON, _ := json.Marshal(results)
+		_ = e.repo.UpdateResult(context.Background(), id, StatusFailed, resultsJSON, err.Error())
+		return
+	}
+
+	overall := StatusPassed
+	for _, probe := range spec.Resources {
+		if ctx.Err() != nil {
+			overall = StatusCancelled
+			break
+		}
+		result := probeResource(ctx, clientset, probe)
+		results.Resources = append(results.Resources, result)
+		if result.Status != StatusPassed && overall != StatusCancelled {
+			overall = StatusFailed
+		}
+		persist(StatusRunning)
+	}
+
+	if ctx.Err() == nil {
+		for _, hook := range spec.Hooks {
+			if ctx.Err() != nil {
+				overall = StatusCancelled
+				break
+			}
+			result := e.runHook(ctx, clientset, hook)
+			results.Hooks = append(results.Hooks, result)
+			if result.Status != StatusPassed && overall != StatusCancelled {
+				overall = StatusFailed
+			}
+			persist(StatusRunning)
+		}
+	}
+
+	persist(overall)
+}
+
+// probeResource Gets or Lists a single ResourceProbe's target(s) and judges
+// it PASSED, FAILED or UNKNOWN. Name takes precedence over LabelSelector
+// when both are set.
+func probeResource(ctx context.Context, clientset *kubernetes.Clientset, probe ResourceProbe) ResourceResult {
+	result := ResourceResult{Kind: probe.Kind, Namespace: probe.Namespace, Name: probe.Name}
+
+	switch strings.ToLower(probe.Kind) {
+	case "deployment":
+		if probe.Name != "" {
+			d, err := clientset.AppsV1().Deployments(probe.Namespace).Get(ctx, probe.Name, metav1.GetOptions{})
+			if err != nil {
+				return failedOrUnknown(result, err)
+			}
+			if d.Status.ReadyReplicas < *d.Spec.Replicas {
+				result.Status = StatusFailed
+				result.Message = fmt.Sprintf("%d/%d replicas ready", d.Status.ReadyReplicas, *d.Spec.Replicas)
+				return result
+			}
+			result.Status = StatusPassed
+			return result
+		}
+		list, err := clientset.AppsV1().Deployments(probe.Namespace).List(ctx, metav1.ListOptions{LabelSelector: probe.LabelSelector})
+		if err != nil {
+			return failedOrUnknown(result, err)
+		}
+		if len(list.Items) == 0 {
+			result.Status = StatusUnknown
+			result.Message = "no deployments matched the label selector"
+			return result
+		}
+		for _, d := range list.Items {
+			if d.Status.ReadyReplicas < *d.Spec.Replicas {
+				result.Status = StatusFailed
+				result.Message = fmt.Sprintf("deployment '%s' has %d/%d replicas ready", d.Name, d.Status.ReadyReplicas, *d.Spec.Replicas)
+				return result
+			}
+		}
+		result.Status = StatusPassed
+		return result
+
+	case "pod":
+		if probe.Name != "" {
+			p, err := clientset.CoreV1().Pods(probe.Namespace).Get(ctx, probe.Name, metav1.GetOptions{})
+			if err != nil {
+				return failedOrUnknown(result, err)
+			}
+			return judgePodPhase(result, p)
+		}
+		list, err := clientset.CoreV1().Pods(probe.Namespace).List(ctx, metav1.ListOptions{LabelSelector: probe.LabelSelector})
+		if err != nil {
+			return failedOrUnknown(result, err)
+		}
+		if len(list.Items) == 0 {
+			result.Status = StatusUnknown
+			result.Message = "no pods matched the label selector"
+			return result
+		}
+		for _, p := range list.Items {
+			if judged := judgePodPhase(result, &p); judged.Status != StatusPassed {
+				return judged
+			}
+		}
+		result.Status = StatusPassed
+		return result
+
+	case "service":
+		if probe.Name != "" {
+			if _, err := clientset.CoreV1().Services(probe.Namespace).Get(ctx, probe.Name, metav1.GetOptions{}); err != nil {
+				return failedOrUnknown(result, err)
+			}
+			result.Status = StatusPassed
+			return result
+		}
+		list, err := clientset.CoreV1().Services(probe.Namespace).List(ctx, metav1.ListOptions{LabelSelector: probe.LabelSelector})
+		if err != nil {
+			return failedOrUnknown(result, err)
+		}
+		if len(list.Items) == 0 {
+			result.Status = StatusUnknown
+			result.Message = "no services matched the label selector"
+			return result
+		}
+		result.Status = StatusPassed
+		return result
+
+	default:
+		result.Status = StatusUnknown
+		result.Message = fmt.Sprintf("unsupported resource kind '%s'", probe.Kind)
+		return result
+	}
+}
+
+// failedOrUnknown classifies a Get/List error: a missing resource is FAILED
+// (the thing being healthchecked is absent), anything else is UNKNOWN (the
+// probe itself couldn't be completed).
+func failedOrUnknown(result ResourceResult, err error) ResourceResult {
+	if apierrors.IsNotFound(err) {
+		result.Status = StatusFailed
+	} else {
+		result.Status = StatusUnknown
+	}
+	result.Message = err.Error()
+	return result
+}
+
+// judgePodPhase reports a pod as PASSED only once it is Running (and all
+// containers ready) or has Succeeded.
+func judgePodPhase(result ResourceResult, pod *corev1.Pod) ResourceResult {
+	result.Name = pod.Name
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		result.Status = StatusPassed
+		return result
+	case corev1.PodRunning:
+		for _, cs := range pod.Status.ContainerStatuses {
+			if !cs.Ready {
+				result.Status = StatusFailed
+				result.Message = fmt.Sprintf("container '%s' is not ready", cs.Name)
+				return result
+			}
+		}
+		result.Status = StatusPassed
+		return result
+	case corev1.PodFailed:
+		result.Status = StatusFailed
+		result.Message = pod.Status.Reason
+		return result
+	default:
+		result.Status = StatusFailed
+		result.Message = fmt.Sprintf("pod is in phase '%s'", pod.Status.Phase)
+		return result
+	}
+}
+
+// runHook creates hook's pod, waits for it to complete (or ctx to be
+// cancelled), captures its logs into a ring buffer, and always deletes the
+// pod it created before returning.
+func (e *Engine) runHook(ctx context.Context, clientset *kubernetes.Clientset, hook TestHook) HookResult {
+	result := HookResult{Name: hook.Name}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("kylon-healthcheck-%s-", hook.Name),
+			Namespace:    hook.Namespace,
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{
+				{Name: "hook", Image: hook.Image, Command: hook.Command},
+			},
+		},
+	}
+
+	created, err := clientset.CoreV1().Pods(hook.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		result.Status = StatusFailed
+		result.Message = err.Error()
+		return result
+	}
+	defer e.deleteHookPod(clientset, hook.Namespace, created.Name)
+
+	phase, err := e.waitForHookPod(ctx, clientset, hook.Namespace, created.Name)
+	if err != nil {
+		result.Status = StatusFailed
+		result.Message = err.Error()
+		result.Logs = e.fetchHookLogs(clientset, hook.Namespace, created.Name)
+		return result
+	}
+
+	result.Logs = e.fetchHookLogs(clientset, hook.Namespace, created.Name)
+	if phase == corev1.PodSucceeded {
+		result.Status = StatusPassed
+	} else {
+		result.Status = StatusFailed
+		result.Message = fmt.Sprintf("hook pod finished in phase '%s'", phase)
+	}
+	return result
+}
+
+// waitForHookPod polls created's phase until it leaves Running/Pending, ctx
+// is cancelled, or hookTimeout elapses.
+func (e *Engine) waitForHookPod(ctx context.Context, clientset *kubernetes.Clientset, namespace, name string) (corev1.PodPhase, error) {
+	deadline := time.Now().Add(hookTimeout)
+	ticker := time.NewTicker(hookPollInterval)
+	defer ticker.Stop()
+
+	for {
+		pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			return pod.Status.Phase, nil
+		}
+		if time.Now().After(deadline) {
+			return pod.Status.Phase, fmt.Errorf("hook pod '%s' did not complete within %s", name, hookTimeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return pod.Status.Phase, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// fetchHookLogs streams name's logs into a LogBuffer and returns its
+// captured contents. Failure to fetch logs is reported in-line rather than
+// failing the hook itself, since the hook's exit phase is the authoritative
+// result.
+func (e *Engine) fetchHookLogs(clientset *kubernetes.Clientset, namespace, name string) string {
+	stream, err := clientset.CoreV1().Pods(namespace).GetLogs(name, &corev1.PodLogOptions{}).Stream(context.Background())
+	if err != nil {
+		return fmt.Sprintf("failed to fetch hook logs: %v", err)
+	}
+	defer stream.Close()
+
+	buf := NewLogBuffer(defaultLogBufferCapacity)
+	if _, err := io.Copy(buf, stream); err != nil {
+		e.log.WithField("pod", name).WithError(err).Warn("Healthcheck hook log capture ended with an error.")
+	}
+	return buf.String()
+}
+
+// deleteHookPod removes a hook pod the Engine created, using a fresh
+// background context so cleanup still happens after the run's own context
+// has been cancelled (e.g. via Engine.Cancel).
+func (e *Engine) deleteHookPod(clientset *kubernetes.Clientset, namespace, name string) {
+	if err := clientset.CoreV1().Pods(namespace).Delete(context.Background(), name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		e.log.WithField("pod", name).WithError(err).Warn("Failed to clean up healthcheck hook pod.")
+	}
+}
@@ -0,0 +1,69 @@
+/**
+ * @File: logbuffer.go
+ * @Title: Ring Buffer for Captured Hook Logs
+ * @Description: A small fixed-capacity, io.Writer-compatible ring buffer used
+ * @Description: to capture a test hook pod's logs as they stream in, without
+ * @Description: letting a runaway or looping hook grow a run's stored results
+ * @Description: without bound.
+ * @Author: thesyscoder/kylon (github.com/thesyscoder)
+ */
+
+package healthcheck
+
+import "sync"
+
+// defaultLogBufferCapacity bounds how many trailing bytes of a single hook
+// pod's logs are retained; older bytes are overwritten as new ones arrive.
+const defaultLogBufferCapacity = 64 * 1024
+
+// LogBuffer is a mutex-guarded circular byte buffer. It implements io.Writer
+// so it can be used directly as the destination of io.Copy from a pod's log
+// stream (see logs.handler.go's streamContainerLogs for the same
+// Stream(ctx)-then-read pattern).
+type LogBuffer struct {
+	mu   sync.Mutex
+	buf  []byte
+	pos  int
+	full bool
+}
+
+// NewLogBuffer creates a LogBuffer with the given byte capacity, falling
+// back to defaultLogBufferCapacity when capacity <= 0.
+func NewLogBuffer(capacity int) *LogBuffer {
+	if capacity <= 0 {
+		capacity = defaultLogBufferCapacity
+	}
+	return &LogBuffer{buf: make([]byte, capacity)}
+}
+
+// Write appends p to the buffer, overwriting the oldest bytes once capacity
+// is reached. It never errors or blocks.
+func (b *LogBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, c := range p {
+		b.buf[b.pos] = c
+		b.pos++
+		if b.pos == len(b.buf) {
+			b.pos = 0
+			b.full = true
+		}
+	}
+	return len(p), nil
+}
+
+// String returns the buffer's current contents in chronological order.
+func (b *LogBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.full {
+		return string(b.buf[:b.pos])
+	}
+
+	out := make([]byte, len(b.buf))
+	n := copy(out, b.buf[b.pos:])
+	copy(out[n:], b.buf[:b.pos])
+	return string(out)
+}
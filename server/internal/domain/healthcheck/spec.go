@@ -0,0 +1,75 @@
+/**
+ * @File: spec.go
+ * @Title: Healthcheck Spec and Result Types
+ * @Description: Defines the user-supplied healthcheck specification (which
+ * @Description: resources to probe and which test hooks to run) and the
+ * @Description: results the Engine produces from it, modeled on ONAP
+ * @Description: multicloud-k8s's instance-healthcheck API.
+ * @Author: thesyscoder/kylon (github.com/thesyscoder)
+ */
+
+package healthcheck
+
+// Status values for a Healthcheck run as a whole, and for each individual
+// resource/hook probed within it.
+const (
+	StatusRunning   = "RUNNING"
+	StatusPassed    = "PASSED"
+	StatusFailed    = "FAILED"
+	StatusUnknown   = "UNKNOWN"
+	StatusCancelled = "CANCELLED"
+)
+
+// ResourceProbe identifies a single Kubernetes resource (or set of
+// resources, via LabelSelector) to Get/List and judge ready. Kind is one of
+// "Deployment", "Pod" or "Service" (case-insensitive). Either Name or
+// LabelSelector must be set; Name takes precedence when both are.
+type ResourceProbe struct {
+	Kind          string `json:"kind" binding:"required"`
+	Namespace     string `json:"namespace" binding:"required"`
+	Name          string `json:"name,omitempty"`
+	LabelSelector string `json:"labelSelector,omitempty"`
+}
+
+// TestHook describes a short-lived Pod the Engine runs as an additional
+// probe beyond a plain resource Get/List, analogous to a Helm test hook.
+// Its logs are captured into a ring buffer and returned with the run's
+// results.
+type TestHook struct {
+	Name      string   `json:"name" binding:"required"`
+	Namespace string   `json:"namespace" binding:"required"`
+	Image     string   `json:"image" binding:"required"`
+	Command   []string `json:"command,omitempty"`
+}
+
+// Spec is a full healthcheck run request: the resources to probe plus any
+// test hooks to execute against the target cluster.
+type Spec struct {
+	Resources []ResourceProbe `json:"resources"`
+	Hooks     []TestHook      `json:"hooks,omitempty"`
+}
+
+// ResourceResult is the outcome of probing a single ResourceProbe.
+type ResourceResult struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name,omitempty"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+}
+
+// HookResult is the outcome of running a single TestHook, including its
+// captured pod logs.
+type HookResult struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+	Logs    string `json:"logs,omitempty"`
+}
+
+// Results is the full set of outcomes for a run, JSON-encoded into
+// models.Healthcheck.ResultsJSON as the Engine makes progress.
+type Results struct {
+	Resources []ResourceResult `json:"resources,omitempty"`
+	Hooks     []HookResult     `json:"hooks,omitempty"`
+}
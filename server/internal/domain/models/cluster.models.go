@@ -10,8 +10,34 @@ import (
 type Cluster struct {
 	ID uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
 
-	Name       string `gorm:"uniqueIndex;not null"`
-	Kubeconfig string `gorm:"type:text;not null" json:"kubeconfig"`
+	Name string `gorm:"uniqueIndex;not null"`
+
+	// Source records how this cluster's kubeconfig was obtained ("kubeconfig",
+	// "in-cluster" or "serviceaccount-token"; see types.ClusterSource). All
+	// three modes still end up as an envelope-encrypted kubeconfig below, so
+	// the scheduler and ClientCache need no source-specific rebuild logic --
+	// Source exists purely so operators can see and audit how a cluster was
+	// registered.
+	Source string `gorm:"not null;default:'kubeconfig'" json:"source"`
+
+	// KubeconfigCiphertext and DEKID hold the envelope-encrypted kubeconfig
+	// for this cluster; see pkg/secrets. The plaintext kubeconfig is never
+	// persisted to disk or to the database, and is never serialized to JSON.
+	KubeconfigCiphertext []byte `gorm:"type:bytea;not null" json:"-"`
+	DEKID                string `gorm:"not null" json:"-"`
+
+	// ServerVersion, APIEndpoint, NodeCount, LastSyncAt, LastHealthyAt and
+	// LastError are observed during the registration reachability probe and
+	// kept fresh by the background scheduler (internal/app/scheduler), so
+	// operators can see connectivity status without Kylon re-probing on
+	// every read. LastSyncAt is updated on every scheduler pass regardless
+	// of outcome; LastHealthyAt only on a successful one.
+	ServerVersion string     `json:"server_version"`
+	APIEndpoint   string     `json:"api_endpoint"`
+	NodeCount     int        `json:"node_count"`
+	LastSyncAt    *time.Time `json:"last_sync_at"`
+	LastHealthyAt *time.Time `json:"last_healthy_at"`
+	LastError     string     `json:"last_error,omitempty"`
 
 	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Healthcheck persists one run of a user-defined healthcheck.Spec against a
+// registered cluster -- see internal/domain/healthcheck.Engine, which is the
+// only writer of SpecJSON/ResultsJSON/Status/Error.
+type Healthcheck struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	ClusterID uuid.UUID `gorm:"type:uuid;not null;index" json:"cluster_id"`
+
+	// Status is one of RUNNING, PASSED, FAILED or CANCELLED; see
+	// healthcheck.Status*.
+	Status string `gorm:"not null;default:'RUNNING'" json:"status"`
+
+	// SpecJSON and ResultsJSON hold the JSON-encoded healthcheck.Spec this run
+	// was started with and, once available, its healthcheck.Results. They are
+	// opaque to the repository layer and decoded only by the engine.
+	SpecJSON    []byte `gorm:"type:jsonb;not null" json:"-"`
+	ResultsJSON []byte `gorm:"type:jsonb" json:"-"`
+
+	// Error holds the reason Status is FAILED, when that reason isn't already
+	// captured per-resource or per-hook inside ResultsJSON (e.g. the cluster's
+	// client could not be resolved at all).
+	Error string `json:"error,omitempty"`
+
+	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+}
+
+func (h *Healthcheck) BeforeCreate(tx *gorm.DB) (err error) {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	return
+}
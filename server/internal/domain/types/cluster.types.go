@@ -6,8 +6,91 @@
 
 package types
 
-// RegisterClusterRequest represents the request body for registering a new cluster.
+// ClusterSource identifies how a registered cluster's credentials were
+// obtained, and therefore how the scheduler and ClientCache should expect
+// its kubeconfig to have been produced.
+type ClusterSource string
+
+const (
+	// ClusterSourceKubeconfig is a cluster registered from an operator-supplied
+	// kubeconfig blob (the original, and still default, registration mode).
+	ClusterSourceKubeconfig ClusterSource = "kubeconfig"
+	// ClusterSourceInCluster is the cluster Kylon itself runs on, self-registered
+	// via rest.InClusterConfig() -- no kubeconfig is ever uploaded.
+	ClusterSourceInCluster ClusterSource = "in-cluster"
+	// ClusterSourceServiceAccountToken is a cluster registered from a bare
+	// API server URL, CA certificate and bearer token, with the kubeconfig
+	// synthesized server-side.
+	ClusterSourceServiceAccountToken ClusterSource = "serviceaccount-token"
+)
+
+// RegisterClusterRequest represents the request body for registering a new
+// cluster via the serviceaccount-token mode (see ClusterHandler.RegisterClusterFromToken).
+// The kubeconfig-blob mode still registers via multipart/form-data upload
+// (see ClusterHandler.RegisterCluster); the in-cluster mode takes no body
+// at all (see ClusterHandler.RegisterInClusterCluster).
 type RegisterClusterRequest struct {
-	Name       string `json:"name" binding:"required"`       // Name of the cluster.
-	Kubeconfig string `json:"kubeconfig" binding:"required"` // Kubeconfig content for accessing the cluster.
+	Name   string        `json:"name" binding:"required"`
+	Source ClusterSource `json:"source,omitempty"` // not read; RegisterClusterFromToken always records ClusterSourceServiceAccountToken
+
+	// APIServerURL and BearerToken are required (CACert is optional) for
+	// ClusterSourceServiceAccountToken; Kylon synthesizes a kubeconfig from
+	// them rather than accepting one directly. Namespace is optional and
+	// becomes the synthesized kubeconfig's default namespace.
+	APIServerURL string `json:"apiServerURL,omitempty"`
+	CACert       string `json:"caCert,omitempty"`
+	BearerToken  string `json:"bearerToken,omitempty"`
+	Namespace    string `json:"namespace,omitempty"`
+
+	// Force registers the cluster even if its reachability probe fails,
+	// persisting it in a degraded/unreachable state instead of rejecting the
+	// request.
+	Force bool `json:"force,omitempty"`
+}
+
+// PodSummary is a trimmed-down view of a Kubernetes pod, returned by the
+// per-cluster pod listing endpoint.
+type PodSummary struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Status    string `json:"status"`
+	Ready     string `json:"ready"`
+	Node      string `json:"node"`
+}
+
+// ClusterSummary is the trimmed-down view of a registered cluster returned
+// by GET /api/v1/clusters, including what the registration probe (or, if it
+// failed and force=true was set, a later scheduler pass) last observed about
+// its reachability.
+type ClusterSummary struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Source        string `json:"source"`
+	Reachable     bool   `json:"reachable"`
+	ServerVersion string `json:"server_version,omitempty"`
+	APIEndpoint   string `json:"api_endpoint,omitempty"`
+	LastHealthyAt string `json:"last_healthy_at,omitempty"`
+	LastError     string `json:"last_error,omitempty"`
+	// LastCheckedAt and LatencyMS reflect the scheduler's most recent
+	// reconciliation pass over this cluster, as cached in-memory by
+	// services.ClusterManager; they are omitted until that first pass
+	// completes.
+	LastCheckedAt string `json:"last_checked_at,omitempty"`
+	LatencyMS     int64  `json:"latency_ms,omitempty"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+}
+
+// ClusterStatus is the reconciliation status of a registered cluster, as last
+// observed by the scheduler (see internal/app/scheduler).
+type ClusterStatus struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Reachable     bool   `json:"reachable"`
+	ServerVersion string `json:"server_version,omitempty"`
+	APIEndpoint   string `json:"api_endpoint,omitempty"`
+	NodeCount     int    `json:"node_count"`
+	LastSyncAt    string `json:"last_sync_at,omitempty"`
+	LastHealthyAt string `json:"last_healthy_at,omitempty"`
+	LastError     string `json:"last_error,omitempty"`
 }
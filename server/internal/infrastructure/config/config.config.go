@@ -29,6 +29,8 @@ type Config struct {
 	Kubernetes KubernetesConfig `yaml:"kubernetes"`
 	AI         AIConfig         `yaml:"ai"`
 	Scheduler  SchedulerConfig  `yaml:"scheduler"`
+	Secrets    SecretsConfig    `yaml:"secrets"`
+	Cluster    ClusterConfig    `yaml:"cluster"`
 }
 
 type AppConfig struct {
@@ -41,6 +43,17 @@ type AppConfig struct {
 	WriteTimeout    time.Duration `yaml:"writeTimeout"`
 	IdleTimeout     time.Duration `yaml:"idleTimeout"`
 	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+	// EnableProfiling mounts the /debug/pprof/* endpoints. Defaults to false;
+	// only enable it in trusted environments, as pprof exposes runtime internals.
+	EnableProfiling bool `yaml:"enableProfiling" env:"APP_ENABLE_PROFILING"`
+	// ClusterUnreachableQuorum is the fraction (0.0-1.0) of registered
+	// clusters that must be unreachable before it degrades /healthz's and
+	// /readyz's overall status; below this threshold, individual unreachable
+	// clusters are still reported in the response's Dependencies map but
+	// don't fail the whole health check -- one bad kubeconfig shouldn't take
+	// down an endpoint serving many clusters. Defaults to 0.5 (half of the
+	// registered clusters) when unset or out of range.
+	ClusterUnreachableQuorum float64 `yaml:"clusterUnreachableQuorum" env:"APP_CLUSTER_UNREACHABLE_QUORUM"`
 }
 
 type LogConfig struct {
@@ -52,7 +65,7 @@ type DatabaseConfig struct {
 	Host                  string        `env:"DB_HOST" yaml:"host"`
 	Port                  string        `env:"DB_PORT" yaml:"port"`
 	User                  string        `env:"DB_USER" yaml:"user"`
-	Password              string        `env:"DB_PASSWORD" yaml:"password"`
+	Password              string        `env:"DB_PASSWORD" yaml:"password" sensitive:"true"`
 	Name                  string        `env:"DB_NAME" yaml:"name"`
 	SslMode               string        `env:"DB_SSL_MODE" yaml:"sslMode"`
 	MaxConnections        int           `yaml:"maxConnections"`
@@ -79,6 +92,58 @@ type SchedulerConfig struct {
 	IntervalMinutes int `yaml:"intervalMinutes"`
 }
 
+// ClusterConfig holds settings that affect how clusters are registered,
+// independent of any single registration request.
+type ClusterConfig struct {
+	// AutoRegisterInCluster, when true, makes the server self-register the
+	// cluster it is running on at startup via rest.InClusterConfig() -- the
+	// same synthesized-kubeconfig path RegisterInClusterCluster uses -- under
+	// the well-known name "in-cluster". It is a no-op outside a Kubernetes
+	// pod, since InClusterConfig() fails when the expected service account
+	// token/CA are absent.
+	AutoRegisterInCluster bool `yaml:"autoRegisterInCluster" env:"CLUSTER_AUTO_REGISTER_INCLUSTER"`
+}
+
+// SecretsConfig selects the KeyService backend used to envelope-encrypt
+// kubeconfigs at rest and carries that backend's connection details. Exactly
+// one of the backend-specific blocks below is consulted, chosen by Backend.
+type SecretsConfig struct {
+	// Backend selects the KeyService implementation: "local", "aws-kms",
+	// "gcp-kms", or "vault".
+	Backend string             `yaml:"backend" env:"SECRETS_BACKEND"`
+	Local   LocalKeyConfig     `yaml:"local"`
+	AWSKMS  AWSKMSKeyConfig    `yaml:"awsKms"`
+	GCPKMS  GCPKMSKeyConfig    `yaml:"gcpKms"`
+	Vault   VaultTransitConfig `yaml:"vault"`
+}
+
+// LocalKeyConfig configures the "local" KeyService, which wraps DEKs with a
+// master key read from an environment variable or file. Intended for
+// development and single-node deployments, not production KMS use.
+type LocalKeyConfig struct {
+	MasterKeyEnv  string `yaml:"masterKeyEnv" env:"SECRETS_LOCAL_MASTER_KEY" sensitive:"true"`
+	MasterKeyFile string `yaml:"masterKeyFile"`
+}
+
+// AWSKMSKeyConfig configures the "aws-kms" KeyService backend.
+type AWSKMSKeyConfig struct {
+	KeyID  string `yaml:"keyId" env:"SECRETS_AWS_KMS_KEY_ID"`
+	Region string `yaml:"region" env:"SECRETS_AWS_REGION"`
+}
+
+// GCPKMSKeyConfig configures the "gcp-kms" KeyService backend.
+type GCPKMSKeyConfig struct {
+	KeyName string `yaml:"keyName" env:"SECRETS_GCP_KMS_KEY_NAME"`
+}
+
+// VaultTransitConfig configures the "vault" KeyService backend, which wraps
+// DEKs using Vault's Transit secrets engine.
+type VaultTransitConfig struct {
+	Address string `yaml:"address" env:"VAULT_ADDR"`
+	Token   string `yaml:"tokenEnv" env:"VAULT_TOKEN" sensitive:"true"`
+	KeyName string `yaml:"keyName" env:"SECRETS_VAULT_KEY_NAME"`
+}
+
 var (
 	cfg     *Config
 	once    sync.Once
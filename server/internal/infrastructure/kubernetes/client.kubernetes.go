@@ -1,8 +1,11 @@
 /**
  * @File: kubernetes.go
- * @Title: Kubernetes Client Management
- * @Description: Handles the initialization and retrieval of the Kubernetes Clientset,
- * @Description: supporting both in-cluster and kubeconfig-based configurations.
+ * @Title: Control-Plane Kubernetes Client Management
+ * @Description: Handles the initialization and retrieval of the single, process-wide
+ * @Description: Kubernetes Clientset for the cluster Kylon itself runs on (the
+ * @Description: "control plane"), supporting both in-cluster and kubeconfig-based
+ * @Description: configurations. Clients for clusters registered by operators are
+ * @Description: obtained from ClientCache instead; see clientcache.go.
  * @Author: thesyscoder (github.com/thesyscoder)
  */
 
@@ -24,7 +27,9 @@ import (
 // log is the logger instance for this package, providing contextual logging for Kubernetes client operations.
 var log = logger.GetLogger().WithField("component", "kubernetes_client")
 
-// clientSet holds the singleton Kubernetes Clientset instance after successful initialization.
+// clientSet holds the singleton control-plane Kubernetes Clientset instance
+// after successful initialization. It always points at the cluster Kylon is
+// deployed into, never at a registered target cluster.
 var clientSet *kubernetes.Clientset
 
 // initOnce ensures that InitKubernetesClient is called only once across the application's lifetime.
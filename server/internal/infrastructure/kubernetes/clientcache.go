@@ -0,0 +1,128 @@
+/**
+ * @File: clientcache.go
+ * @Title: Per-Cluster Kubernetes Client Cache
+ * @Description: Maintains a cache of Kubernetes Clientsets for registered clusters,
+ * @Description: built on demand from each cluster's stored (encrypted) kubeconfig and
+ * @Description: keyed by cluster UUID, so the rest of the application never has to
+ * @Description: assume there is a single target Kubernetes API server.
+ * @Author: thesyscoder (github.com/thesyscoder)
+ */
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/thesyscoder/kylon/internal/infrastructure/repositories"
+	"github.com/thesyscoder/kylon/pkg/configz"
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+	"github.com/thesyscoder/kylon/pkg/secrets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// ClientCache lazily builds and caches a *kubernetes.Clientset per registered
+// cluster, keyed by the cluster's UUID. Unlike the process-wide control-plane
+// client above (which talks to the cluster Kylon itself runs on), entries in
+// ClientCache talk to the clusters operators have registered via the
+// ClusterHandler API, each of which carries its own (encrypted) kubeconfig.
+type ClientCache struct {
+	mu          sync.RWMutex
+	clients     map[uuid.UUID]*kubernetes.Clientset
+	clusterRepo repositories.ClusterRepository
+	keyService  secrets.KeyService
+	log         *logrus.Logger
+	// configz publishes the set of currently-cached cluster IDs under
+	// GET /configz, so operators can see which clusters are loaded without
+	// reading the database.
+	configz *configz.Config
+}
+
+// NewClientCache creates an empty ClientCache backed by the given
+// ClusterRepository and KeyService.
+func NewClientCache(clusterRepo repositories.ClusterRepository, keyService secrets.KeyService, log *logrus.Logger) *ClientCache {
+	c := &ClientCache{
+		clients:     make(map[uuid.UUID]*kubernetes.Clientset),
+		clusterRepo: clusterRepo,
+		keyService:  keyService,
+		log:         log,
+		configz:     configz.New("clusters"),
+	}
+	c.publishConfigz()
+	return c
+}
+
+// publishConfigz snapshots the currently-cached cluster IDs to the "clusters"
+// configz entry. Callers must hold c.mu (read or write) when calling this.
+func (c *ClientCache) publishConfigz() {
+	loaded := make([]uuid.UUID, 0, len(c.clients))
+	for id := range c.clients {
+		loaded = append(loaded, id)
+	}
+	c.configz.Set(struct {
+		LoadedClusters []uuid.UUID `json:"loadedClusters"`
+	}{LoadedClusters: loaded})
+}
+
+// ClientFor returns a cached Clientset for clusterID, building and caching one
+// from the cluster's decrypted kubeconfig on first use.
+func (c *ClientCache) ClientFor(ctx context.Context, clusterID uuid.UUID) (*kubernetes.Clientset, error) {
+	c.mu.RLock()
+	if cs, ok := c.clients[clusterID]; ok {
+		c.mu.RUnlock()
+		return cs, nil
+	}
+	c.mu.RUnlock()
+
+	restConfig, err := c.RESTConfigFor(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	cs, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeK8sClientInitFailed,
+			fmt.Sprintf("Failed to build Kubernetes clientset for cluster '%s'.", clusterID),
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	c.mu.Lock()
+	c.clients[clusterID] = cs
+	c.publishConfigz()
+	c.mu.Unlock()
+
+	c.log.WithField("cluster_id", clusterID).Info("Cached new Kubernetes clientset for cluster.")
+	return cs, nil
+}
+
+// RESTConfigFor resolves the rest.Config for clusterID by decrypting the
+// cluster's stored kubeconfig via ResolveKubeconfig. It does not populate the
+// cache; callers that only need a rest.Config (e.g. for discovery or dynamic
+// clients) can use this directly instead of going through ClientFor.
+func (c *ClientCache) RESTConfigFor(ctx context.Context, clusterID uuid.UUID) (*rest.Config, error) {
+	return ResolveKubeconfig(ctx, c.clusterRepo, c.keyService, clusterID)
+}
+
+// Invalidate drops clusterID's cached clientset, if any, forcing the next
+// ClientFor call to rebuild it from the cluster's current stored kubeconfig.
+// Callers should invoke this whenever a cluster's kubeconfig is updated or the
+// cluster is deleted.
+func (c *ClientCache) Invalidate(clusterID uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.clients[clusterID]; ok {
+		delete(c.clients, clusterID)
+		c.publishConfigz()
+		c.log.WithField("cluster_id", clusterID).Info("Invalidated cached Kubernetes clientset for cluster.")
+	}
+}
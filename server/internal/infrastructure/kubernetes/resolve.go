@@ -0,0 +1,173 @@
+/**
+ * @File: resolve.go
+ * @Title: Kubeconfig Resolution
+ * @Description: Decrypts a registered cluster's stored kubeconfig on demand,
+ * @Description: the one place consumers should go through instead of reading
+ * @Description: models.Cluster.KubeconfigCiphertext directly.
+ * @Author: thesyscoder (github.com/thesyscoder)
+ */
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/thesyscoder/kylon/internal/infrastructure/repositories"
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+	"github.com/thesyscoder/kylon/pkg/secrets"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// ResolveKubeconfig decrypts clusterID's stored kubeconfig and builds a
+// rest.Config from it. This is the one place that should ever touch
+// models.Cluster.KubeconfigCiphertext; everything else should call this (or
+// ResolveKubeconfigFile, or go through a ClientCache) instead.
+func ResolveKubeconfig(ctx context.Context, clusterRepo repositories.ClusterRepository, keyService secrets.KeyService, clusterID uuid.UUID) (*rest.Config, error) {
+	cluster, err := clusterRepo.GetByID(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := keyService.Decrypt(ctx, cluster.KubeconfigCiphertext, cluster.DEKID)
+	if err != nil {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeDecryptionFailed,
+			"Failed to decrypt stored kubeconfig.",
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(plaintext)
+	if err != nil {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeK8sClientInitFailed,
+			"Decrypted kubeconfig could not be parsed into a REST config.",
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+	return restConfig, nil
+}
+
+// ResolveKubeconfigFile decrypts clusterID's stored kubeconfig and writes it
+// to a private (0600) temp file, for the handful of consumers (exec plugins,
+// third-party CLIs invoked as subprocesses) that need a kubeconfig path
+// rather than an in-memory rest.Config. The returned cleanup func removes the
+// temp file and must be called once the caller is done with it.
+func ResolveKubeconfigFile(ctx context.Context, clusterRepo repositories.ClusterRepository, keyService secrets.KeyService, clusterID uuid.UUID) (path string, cleanup func(), err error) {
+	cluster, err := clusterRepo.GetByID(ctx, clusterID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	plaintext, err := keyService.Decrypt(ctx, cluster.KubeconfigCiphertext, cluster.DEKID)
+	if err != nil {
+		return "", nil, customerrors.NewCustomError(
+			customerrors.ErrCodeDecryptionFailed,
+			"Failed to decrypt stored kubeconfig.",
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	tmp, err := os.CreateTemp("", "kylon-kubeconfig-*.yaml")
+	if err != nil {
+		return "", nil, customerrors.NewCustomError(
+			customerrors.ErrCodeInternal,
+			"Failed to create temporary kubeconfig file.",
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+	cleanupFn := func() { os.Remove(tmp.Name()) }
+
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		cleanupFn()
+		return "", nil, customerrors.NewCustomError(customerrors.ErrCodeInternal, "Failed to chmod temporary kubeconfig file.", err, http.StatusInternalServerError, nil)
+	}
+	if _, err := tmp.Write(plaintext); err != nil {
+		tmp.Close()
+		cleanupFn()
+		return "", nil, customerrors.NewCustomError(customerrors.ErrCodeInternal, "Failed to write temporary kubeconfig file.", err, http.StatusInternalServerError, nil)
+	}
+	if err := tmp.Close(); err != nil {
+		cleanupFn()
+		return "", nil, customerrors.NewCustomError(customerrors.ErrCodeInternal, "Failed to finalize temporary kubeconfig file.", err, http.StatusInternalServerError, nil)
+	}
+
+	return tmp.Name(), cleanupFn, nil
+}
+
+// PersistKubeconfigBlob atomically writes clusterID's envelope-encrypted
+// kubeconfig ciphertext to saveDir, named by the cluster's ID. It is a no-op
+// when saveDir is empty. Only kubeconfig-blob registrations call this --
+// in-cluster and serviceaccount-token registrations synthesize their
+// kubeconfig on the fly and are never written to disk. The ciphertext, not
+// the plaintext kubeconfig, is what is persisted, consistent with
+// models.Cluster never holding a plaintext kubeconfig anywhere at rest.
+func PersistKubeconfigBlob(saveDir string, clusterID uuid.UUID, ciphertext []byte) error {
+	if saveDir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(saveDir, 0700); err != nil {
+		return customerrors.NewCustomError(
+			customerrors.ErrCodeInternal,
+			fmt.Sprintf("Failed to create kubeconfig save directory '%s'.", saveDir),
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	dest := filepath.Join(saveDir, clusterID.String()+".enc")
+	tmp, err := os.CreateTemp(saveDir, clusterID.String()+".tmp-*")
+	if err != nil {
+		return customerrors.NewCustomError(
+			customerrors.ErrCodeInternal,
+			"Failed to create temporary kubeconfig blob file.",
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return customerrors.NewCustomError(customerrors.ErrCodeInternal, "Failed to chmod temporary kubeconfig blob file.", err, http.StatusInternalServerError, nil)
+	}
+	if _, err := tmp.Write(ciphertext); err != nil {
+		tmp.Close()
+		return customerrors.NewCustomError(customerrors.ErrCodeInternal, "Failed to write temporary kubeconfig blob file.", err, http.StatusInternalServerError, nil)
+	}
+	if err := tmp.Close(); err != nil {
+		return customerrors.NewCustomError(customerrors.ErrCodeInternal, "Failed to finalize temporary kubeconfig blob file.", err, http.StatusInternalServerError, nil)
+	}
+
+	// Rename is atomic on the same filesystem, so readers never observe a
+	// partially-written blob file.
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return customerrors.NewCustomError(
+			customerrors.ErrCodeInternal,
+			fmt.Sprintf("Failed to move kubeconfig blob into place at '%s'.", dest),
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	return nil
+}
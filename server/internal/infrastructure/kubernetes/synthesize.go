@@ -0,0 +1,98 @@
+/**
+ * @File: synthesize.go
+ * @Title: Kubeconfig Synthesis
+ * @Description: Builds an in-memory kubeconfig for registration modes that
+ * @Description: never involve an operator uploading one -- in-cluster
+ * @Description: self-registration and bare serviceaccount-token credentials --
+ * @Description: so the rest of the application (ClientCache, the scheduler,
+ * @Description: ResolveKubeconfig) can treat every registered cluster
+ * @Description: uniformly as an encrypted kubeconfig blob.
+ * @Author: thesyscoder (github.com/thesyscoder)
+ */
+
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// clusterKey and userKey name the single cluster/user/context entries in a
+// synthesized kubeconfig; there is only ever one of each.
+const (
+	clusterKey = "cluster"
+	userKey    = "user"
+	contextKey = "context"
+)
+
+// SynthesizeInClusterKubeconfig builds a kubeconfig from rest.InClusterConfig(),
+// for self-registering the cluster Kylon itself runs on without ever asking
+// an operator to upload one.
+func SynthesizeInClusterKubeconfig() ([]byte, error) {
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeK8sClientInitFailed,
+			"Kylon is not running inside a Kubernetes pod; in-cluster config is unavailable.",
+			err,
+			http.StatusBadRequest,
+			nil,
+		)
+	}
+
+	return synthesizeKubeconfig(restConfig.Host, restConfig.CAData, restConfig.BearerToken, "")
+}
+
+// SynthesizeTokenKubeconfig builds a kubeconfig from a bare API server URL,
+// PEM-encoded CA certificate and bearer token, for registering a cluster that
+// was never handed a kubeconfig file at all.
+func SynthesizeTokenKubeconfig(apiServerURL, caCertPEM, bearerToken, namespace string) ([]byte, error) {
+	if apiServerURL == "" || bearerToken == "" {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeInvalidInput,
+			"apiServerURL and bearerToken are required to synthesize a kubeconfig.",
+			nil,
+			http.StatusBadRequest,
+			nil,
+		)
+	}
+
+	return synthesizeKubeconfig(apiServerURL, []byte(caCertPEM), bearerToken, namespace)
+}
+
+// synthesizeKubeconfig assembles a single-cluster, single-user kubeconfig
+// and serializes it with clientcmd, the same format ResolveKubeconfig expects
+// when it later decrypts and parses a stored cluster's kubeconfig.
+func synthesizeKubeconfig(host string, caData []byte, bearerToken, namespace string) ([]byte, error) {
+	config := clientcmdapi.NewConfig()
+	config.Clusters[clusterKey] = &clientcmdapi.Cluster{
+		Server:                   host,
+		CertificateAuthorityData: caData,
+	}
+	config.AuthInfos[userKey] = &clientcmdapi.AuthInfo{
+		Token: bearerToken,
+	}
+	config.Contexts[contextKey] = &clientcmdapi.Context{
+		Cluster:   clusterKey,
+		AuthInfo:  userKey,
+		Namespace: namespace,
+	}
+	config.CurrentContext = contextKey
+
+	kubeconfig, err := clientcmd.Write(*config)
+	if err != nil {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeInternal,
+			fmt.Sprintf("Failed to serialize synthesized kubeconfig for '%s'.", host),
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+	return kubeconfig, nil
+}
@@ -9,9 +9,12 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/thesyscoder/kylon/internal/domain/models"
 	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
@@ -22,6 +25,42 @@ import (
 type ClusterRepository interface {
 	Create(ctx context.Context, cluster *models.Cluster) error
 	List(ctx context.Context) ([]models.Cluster, error)
+	// GetByID looks up a single cluster by its primary key. It returns an
+	// ErrCodeResourceNotFound CustomError when no matching row exists.
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Cluster, error)
+	// GetByName looks up a single cluster by its unique Name. It returns an
+	// ErrCodeResourceNotFound CustomError when no matching row exists.
+	GetByName(ctx context.Context, name string) (*models.Cluster, error)
+	// UpdateClusterStatus persists the outcome of a scheduler reconciliation
+	// pass (see internal/app/scheduler) for a single cluster.
+	UpdateClusterStatus(ctx context.Context, id uuid.UUID, status ClusterStatusUpdate) error
+	// UpdateKubeconfig refreshes a cluster's envelope-encrypted kubeconfig and
+	// the registration probe's outcome in place -- used when re-registering an
+	// already-registered cluster (e.g. AutoRegisterInCluster on restart)
+	// instead of inserting a duplicate row under its unique Name.
+	UpdateKubeconfig(ctx context.Context, id uuid.UUID, ciphertext []byte, dekID string, probe ClusterProbeUpdate) error
+}
+
+// ClusterProbeUpdate carries what a registration probe observed about a
+// cluster, for persisting alongside a refreshed kubeconfig; see
+// UpdateKubeconfig. It mirrors the fields RegisterCluster records on initial
+// creation from a *services.KubeconfigProbeResult.
+type ClusterProbeUpdate struct {
+	ServerVersion string
+	APIEndpoint   string
+	HealthyAt     *time.Time
+	LastError     string
+}
+
+// ClusterStatusUpdate carries the fields the scheduler refreshes after each
+// reconciliation pass over a cluster. SyncedAt is set on every pass; HealthyAt
+// and ServerVersion/NodeCount only reflect the most recent successful probe.
+type ClusterStatusUpdate struct {
+	ServerVersion string
+	NodeCount     int
+	SyncedAt      time.Time
+	HealthyAt     *time.Time
+	LastError     string
 }
 
 // postgresClusterRepository is a PostgreSQL implementation of ClusterRepository.
@@ -77,3 +116,118 @@ func (r *postgresClusterRepository) List(ctx context.Context) ([]models.Cluster,
 	r.log.WithContext(ctx).Infof("Successfully retrieved %d cluster records.", len(clusters))
 	return clusters, nil
 }
+
+// GetByID retrieves a single Cluster record by its primary key.
+func (r *postgresClusterRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Cluster, error) {
+	r.log.WithContext(ctx).WithField("cluster_id", id).Info("Attempting to retrieve cluster record by ID.")
+
+	var cluster models.Cluster
+	if err := r.DB.WithContext(ctx).First(&cluster, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.NewCustomError(
+				customerrors.ErrCodeResourceNotFound,
+				fmt.Sprintf("Cluster '%s' was not found.", id),
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		}
+
+		r.log.WithContext(ctx).WithError(err).Error("Failed to retrieve cluster record by ID.")
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeDatabaseOperationFailed,
+			"Failed to retrieve cluster.",
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	return &cluster, nil
+}
+
+// GetByName retrieves a single Cluster record by its unique Name.
+func (r *postgresClusterRepository) GetByName(ctx context.Context, name string) (*models.Cluster, error) {
+	r.log.WithContext(ctx).WithField("cluster_name", name).Info("Attempting to retrieve cluster record by name.")
+
+	var cluster models.Cluster
+	if err := r.DB.WithContext(ctx).First(&cluster, "name = ?", name).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.NewCustomError(
+				customerrors.ErrCodeResourceNotFound,
+				fmt.Sprintf("Cluster '%s' was not found.", name),
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		}
+
+		r.log.WithContext(ctx).WithError(err).Error("Failed to retrieve cluster record by name.")
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeDatabaseOperationFailed,
+			"Failed to retrieve cluster.",
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	return &cluster, nil
+}
+
+// UpdateKubeconfig refreshes a cluster's envelope-encrypted kubeconfig and
+// probe outcome in place; see ClusterRepository.UpdateKubeconfig.
+func (r *postgresClusterRepository) UpdateKubeconfig(ctx context.Context, id uuid.UUID, ciphertext []byte, dekID string, probe ClusterProbeUpdate) error {
+	r.log.WithContext(ctx).WithField("cluster_id", id).Info("Refreshing cluster kubeconfig in place.")
+
+	updates := map[string]interface{}{
+		"kubeconfig_ciphertext": ciphertext,
+		"dekid":                 dekID,
+		"server_version":        probe.ServerVersion,
+		"api_endpoint":          probe.APIEndpoint,
+		"last_healthy_at":       probe.HealthyAt,
+		"last_error":            probe.LastError,
+	}
+
+	if err := r.DB.WithContext(ctx).Model(&models.Cluster{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		r.log.WithContext(ctx).WithError(err).Error("Failed to refresh cluster kubeconfig.")
+		return customerrors.NewCustomError(
+			customerrors.ErrCodeDatabaseOperationFailed,
+			"Failed to update cluster kubeconfig.",
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	return nil
+}
+
+// UpdateClusterStatus persists the outcome of a scheduler reconciliation pass
+// for a single cluster.
+func (r *postgresClusterRepository) UpdateClusterStatus(ctx context.Context, id uuid.UUID, status ClusterStatusUpdate) error {
+	r.log.WithContext(ctx).WithField("cluster_id", id).Debug("Recording cluster reconciliation status.")
+
+	updates := map[string]interface{}{
+		"last_sync_at": status.SyncedAt,
+		"last_error":   status.LastError,
+	}
+	if status.HealthyAt != nil {
+		updates["server_version"] = status.ServerVersion
+		updates["node_count"] = status.NodeCount
+		updates["last_healthy_at"] = status.HealthyAt
+	}
+
+	if err := r.DB.WithContext(ctx).Model(&models.Cluster{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		r.log.WithContext(ctx).WithError(err).Error("Failed to record cluster reconciliation status.")
+		return customerrors.NewCustomError(
+			customerrors.ErrCodeDatabaseOperationFailed,
+			"Failed to update cluster status.",
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+/**
+ * @File: healthcheck.repository.go
+ * @Title: Healthcheck Repository
+ * @Description: Defines the interface and implements the PostgreSQL repository
+ * @Description: for managing Healthcheck run records.
+ */
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/thesyscoder/kylon/internal/domain/models"
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+	"gorm.io/gorm"
+)
+
+// HealthcheckRepository defines operations for healthcheck run records.
+type HealthcheckRepository interface {
+	Create(ctx context.Context, healthcheck *models.Healthcheck) error
+	// GetByID looks up a single healthcheck run by its primary key. It returns
+	// an ErrCodeResourceNotFound CustomError when no matching row exists.
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Healthcheck, error)
+	// UpdateResult persists the engine's current status/results for a run,
+	// called both incrementally as probes complete and once more on finish.
+	UpdateResult(ctx context.Context, id uuid.UUID, status string, resultsJSON []byte, errMsg string) error
+	// Delete soft-deletes a healthcheck run record.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// postgresHealthcheckRepository is a PostgreSQL implementation of HealthcheckRepository.
+type postgresHealthcheckRepository struct {
+	DB  *gorm.DB
+	log *logrus.Logger
+}
+
+// NewHealthcheckRepository creates a new postgresHealthcheckRepository.
+func NewHealthcheckRepository(db *gorm.DB, log *logrus.Logger) HealthcheckRepository {
+	return &postgresHealthcheckRepository{
+		DB:  db,
+		log: log,
+	}
+}
+
+// Create persists a new Healthcheck run record to the database.
+func (r *postgresHealthcheckRepository) Create(ctx context.Context, healthcheck *models.Healthcheck) error {
+	r.log.WithContext(ctx).WithField("cluster_id", healthcheck.ClusterID).Info("Creating new healthcheck run record.")
+
+	if err := r.DB.WithContext(ctx).Create(healthcheck).Error; err != nil {
+		r.log.WithContext(ctx).WithError(err).Error("Failed to create healthcheck run record in database.")
+		return customerrors.NewCustomError(
+			customerrors.ErrCodeDatabaseOperationFailed,
+			"Failed to create healthcheck run.",
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a single Healthcheck run record by its primary key.
+func (r *postgresHealthcheckRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Healthcheck, error) {
+	var healthcheck models.Healthcheck
+	if err := r.DB.WithContext(ctx).First(&healthcheck, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customerrors.NewCustomError(
+				customerrors.ErrCodeResourceNotFound,
+				fmt.Sprintf("Healthcheck '%s' was not found.", id),
+				err,
+				http.StatusNotFound,
+				nil,
+			)
+		}
+
+		r.log.WithContext(ctx).WithError(err).Error("Failed to retrieve healthcheck run record by ID.")
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeDatabaseOperationFailed,
+			"Failed to retrieve healthcheck run.",
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	return &healthcheck, nil
+}
+
+// UpdateResult persists the engine's current status/results for a run.
+func (r *postgresHealthcheckRepository) UpdateResult(ctx context.Context, id uuid.UUID, status string, resultsJSON []byte, errMsg string) error {
+	updates := map[string]interface{}{
+		"status":       status,
+		"results_json": resultsJSON,
+		"error":        errMsg,
+	}
+
+	if err := r.DB.WithContext(ctx).Model(&models.Healthcheck{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+		r.log.WithContext(ctx).WithError(err).Error("Failed to record healthcheck run result.")
+		return customerrors.NewCustomError(
+			customerrors.ErrCodeDatabaseOperationFailed,
+			"Failed to update healthcheck run.",
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	return nil
+}
+
+// Delete soft-deletes a healthcheck run record.
+func (r *postgresHealthcheckRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.DB.WithContext(ctx).Delete(&models.Healthcheck{}, "id = ?", id).Error; err != nil {
+		r.log.WithContext(ctx).WithError(err).Error("Failed to delete healthcheck run record.")
+		return customerrors.NewCustomError(
+			customerrors.ErrCodeDatabaseOperationFailed,
+			"Failed to delete healthcheck run.",
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	return nil
+}
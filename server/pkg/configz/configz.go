@@ -0,0 +1,131 @@
+/**
+ * @File: configz.go
+ * @Title: Runtime Configuration Registry
+ * @Description: Borrows the componentconfig "configz" pattern: packages
+ * @Description: register a named snapshot of their effective configuration,
+ * @Description: redacted of any field tagged `sensitive:"true"`, which is
+ * @Description: then served as JSON by InstallHandler under GET /configz.
+ * @Author: thesyscoder (github.com/thesyscoder)
+ */
+
+package configz
+
+import (
+	"net/http"
+	"reflect"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Config is a single named entry in the registry. Call Set whenever the
+// underlying configuration changes; InstallHandler serves the latest
+// redacted snapshot of every registered Config.
+type Config struct {
+	name string
+
+	mu    sync.RWMutex
+	value any
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]*Config{}
+)
+
+// New registers (or replaces) a named Config entry and returns it. Callers
+// typically hold on to the returned *Config and call Set on it whenever their
+// configuration is (re)loaded.
+func New(name string) *Config {
+	c := &Config{name: name}
+
+	registryMu.Lock()
+	registry[name] = c
+	registryMu.Unlock()
+
+	return c
+}
+
+// Set stores a redacted snapshot of v, zeroing any field (at any depth) tagged
+// `sensitive:"true"`. v is typically a configuration struct; it is not
+// retained or mutated.
+func (c *Config) Set(v any) {
+	redacted := redact(reflect.ValueOf(v)).Interface()
+
+	c.mu.Lock()
+	c.value = redacted
+	c.mu.Unlock()
+}
+
+// Get returns the most recent redacted snapshot passed to Set, or nil if Set
+// has never been called.
+func (c *Config) Get() any {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.value
+}
+
+// InstallHandler mounts GET /configz on router, returning every registered
+// Config's latest redacted snapshot keyed by its name.
+func InstallHandler(router gin.IRouter) {
+	router.GET("/configz", func(c *gin.Context) {
+		registryMu.RLock()
+		defer registryMu.RUnlock()
+
+		snapshot := make(map[string]any, len(registry))
+		for name, entry := range registry {
+			snapshot[name] = entry.Get()
+		}
+
+		c.JSON(http.StatusOK, snapshot)
+	})
+}
+
+// redact returns a deep copy of rv with every struct field tagged
+// `sensitive:"true"` left at its zero value. Unexported fields are skipped
+// (left zeroed) rather than copied, since they cannot be read via reflection.
+func redact(rv reflect.Value) reflect.Value {
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return rv
+		}
+		out := reflect.New(rv.Type().Elem())
+		out.Elem().Set(redact(rv.Elem()))
+		return out
+	case reflect.Struct:
+		out := reflect.New(rv.Type()).Elem()
+		for i := 0; i < rv.NumField(); i++ {
+			field := rv.Type().Field(i)
+			if !out.Field(i).CanSet() {
+				continue
+			}
+			if field.Tag.Get("sensitive") == "true" {
+				continue
+			}
+			out.Field(i).Set(redact(rv.Field(i)))
+		}
+		return out
+	case reflect.Slice:
+		if rv.IsNil() {
+			return rv
+		}
+		out := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			out.Index(i).Set(redact(rv.Index(i)))
+		}
+		return out
+	case reflect.Map:
+		if rv.IsNil() {
+			return rv
+		}
+		out := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), redact(iter.Value()))
+		}
+		return out
+	default:
+		return rv
+	}
+}
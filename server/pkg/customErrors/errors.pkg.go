@@ -63,6 +63,8 @@ const (
 	ErrCodeConfigValidationFailed = "CONFIG_VALIDATION_FAILED"
 	ErrCodeEnvMissingRequired     = "ENV_MISSING_REQUIRED"
 	ErrCodeSecretLoadFailed       = "SECRET_LOAD_FAILED"
+	ErrCodeEncryptionFailed       = "ENCRYPTION_FAILED"
+	ErrCodeDecryptionFailed       = "DECRYPTION_FAILED"
 
 	// Database/Storage
 	ErrCodeStorageInitFailed        = "STORAGE_INIT_FAILED"
@@ -78,6 +80,10 @@ const (
 	ErrCodeK8sAPIError              = "K8S_API_ERROR"
 	ErrCodeCloudProviderError       = "CLOUD_PROVIDER_ERROR"
 	ErrCodeObjectStorageUnavailable = "OBJECT_STORAGE_UNAVAILABLE"
+	ErrCodeClusterUnreachable       = "CLUSTER_UNREACHABLE"
+	ErrCodeK8sLogStreamFailed       = "K8S_LOG_STREAM_FAILED"
+	ErrCodeStatusCRApplyFailed      = "STATUS_CR_APPLY_FAILED"
+	ErrCodeTrackerReconcileFailed   = "TRACKER_RECONCILE_FAILED"
 
 	// Application/Business Logic
 	ErrCodeInvalidInput        = "INVALID_INPUT"
@@ -0,0 +1,99 @@
+/**
+ * @File: awskms.go
+ * @Title: AWS KMS KeyService
+ * @Description: A KeyService that wraps/unwraps DEKs using an AWS KMS
+ * @Description: customer master key, so the long-lived key material never
+ * @Description: leaves AWS KMS.
+ * @Author: thesyscoder/kylon (github.com/thesyscoder)
+ */
+
+package secrets
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	kmsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/thesyscoder/kylon/internal/infrastructure/config"
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+)
+
+// awsKMSKeyService wraps DEKs via the AWS KMS Encrypt/Decrypt APIs.
+type awsKMSKeyService struct {
+	client *kms.Client
+	keyID  string
+}
+
+// newAWSKMSKeyService builds an awsKMSKeyService from the default AWS
+// credential chain, scoped to the configured region and key ID.
+func newAWSKMSKeyService(ctx context.Context, cfg config.AWSKMSKeyConfig) (*awsKMSKeyService, error) {
+	if cfg.KeyID == "" {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeConfigValidationFailed,
+			"secrets.awsKms.keyId is required when secrets.backend is 'aws-kms'.",
+			nil,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	awsCfg, err := kmsconfig.LoadDefaultConfig(ctx, kmsconfig.WithRegion(cfg.Region))
+	if err != nil {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeSecretLoadFailed,
+			"Failed to load AWS SDK configuration for KMS.",
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	return &awsKMSKeyService{
+		client: kms.NewFromConfig(awsCfg),
+		keyID:  cfg.KeyID,
+	}, nil
+}
+
+// Encrypt seals plaintext under a fresh DEK, then asks AWS KMS to encrypt the
+// DEK itself under the configured customer master key.
+func (s *awsKMSKeyService) Encrypt(ctx context.Context, plaintext []byte) ([]byte, string, error) {
+	dek, err := generateDEK()
+	if err != nil {
+		return nil, "", customerrors.NewCustomError(customerrors.ErrCodeEncryptionFailed, "Failed to generate DEK.", err, http.StatusInternalServerError, nil)
+	}
+
+	out, err := s.client.Encrypt(ctx, &kms.EncryptInput{
+		KeyId:     aws.String(s.keyID),
+		Plaintext: dek,
+	})
+	if err != nil {
+		return nil, "", customerrors.NewCustomError(customerrors.ErrCodeEncryptionFailed, "AWS KMS failed to encrypt the DEK.", err, http.StatusInternalServerError, nil)
+	}
+
+	ciphertext, err := sealWithDEK(plaintext, dek, out.CiphertextBlob)
+	if err != nil {
+		return nil, "", customerrors.NewCustomError(customerrors.ErrCodeEncryptionFailed, "Failed to seal plaintext with DEK.", err, http.StatusInternalServerError, nil)
+	}
+
+	return ciphertext, aws.ToString(out.KeyId), nil
+}
+
+// Decrypt asks AWS KMS to decrypt the wrapped DEK, then opens the sealed plaintext.
+func (s *awsKMSKeyService) Decrypt(ctx context.Context, ciphertext []byte, _ string) ([]byte, error) {
+	wrappedDEK, err := wrappedDEKFromEnvelope(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := s.client.Decrypt(ctx, &kms.DecryptInput{
+		KeyId:          aws.String(s.keyID),
+		CiphertextBlob: wrappedDEK,
+	})
+	if err != nil {
+		return nil, customerrors.NewCustomError(customerrors.ErrCodeDecryptionFailed, "AWS KMS failed to decrypt the DEK.", err, http.StatusInternalServerError, nil)
+	}
+
+	return openEnvelope(ciphertext, out.Plaintext)
+}
@@ -0,0 +1,122 @@
+/**
+ * @File: envelope.go
+ * @Title: Envelope Encryption Helpers
+ * @Description: Shared AES-GCM sealing/opening helpers used by every KeyService
+ * @Description: backend. Each backend is responsible only for wrapping and
+ * @Description: unwrapping the per-secret DEK with its own KEK; sealing the
+ * @Description: actual plaintext is identical regardless of backend.
+ * @Author: thesyscoder (github.com/thesyscoder)
+ */
+
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+)
+
+// dekSize is the size in bytes of a generated AES-256 Data Encryption Key.
+const dekSize = 32
+
+// envelope is the serialized form returned to callers as "ciphertext". It
+// bundles the wrapped DEK alongside the AES-GCM sealed plaintext so Decrypt
+// has everything it needs from the ciphertext itself.
+type envelope struct {
+	WrappedDEK []byte `json:"wrapped_dek"`
+	Nonce      []byte `json:"nonce"`
+	Sealed     []byte `json:"sealed"`
+}
+
+// generateDEK returns a fresh random 256-bit Data Encryption Key.
+func generateDEK() ([]byte, error) {
+	dek := make([]byte, dekSize)
+	if _, err := rand.Read(dek); err != nil {
+		return nil, fmt.Errorf("failed to generate DEK: %w", err)
+	}
+	return dek, nil
+}
+
+// sealWithDEK AES-GCM encrypts plaintext under dek and wraps the result,
+// along with wrappedDEK, into a serialized envelope.
+func sealWithDEK(plaintext, dek, wrappedDEK []byte) ([]byte, error) {
+	block, err := aes.NewCipher(dek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct AES cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct AES-GCM: %w", err)
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := gcm.Seal(nil, nonce, plaintext, nil)
+
+	return json.Marshal(envelope{
+		WrappedDEK: wrappedDEK,
+		Nonce:      nonce,
+		Sealed:     sealed,
+	})
+}
+
+// openEnvelope deserializes ciphertext and AES-GCM decrypts its sealed
+// payload using dek (the already-unwrapped DEK).
+func openEnvelope(ciphertext, dek []byte) ([]byte, error) {
+	var env envelope
+	if err := json.Unmarshal(ciphertext, &env); err != nil {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeDecryptionFailed,
+			"Ciphertext is not a valid envelope.",
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	block, err := aes.NewCipher(dek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct AES cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct AES-GCM: %w", err)
+	}
+
+	plaintext, err := gcm.Open(nil, env.Nonce, env.Sealed, nil)
+	if err != nil {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeDecryptionFailed,
+			"Failed to decrypt envelope: authentication failed.",
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+	return plaintext, nil
+}
+
+// wrappedDEKFromEnvelope extracts the wrapped DEK from a serialized envelope
+// without decrypting the payload, so a KeyService can unwrap it with its KEK
+// before calling openEnvelope.
+func wrappedDEKFromEnvelope(ciphertext []byte) ([]byte, error) {
+	var env envelope
+	if err := json.Unmarshal(ciphertext, &env); err != nil {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeDecryptionFailed,
+			"Ciphertext is not a valid envelope.",
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+	return env.WrappedDEK, nil
+}
@@ -0,0 +1,95 @@
+/**
+ * @File: gcpkms.go
+ * @Title: GCP Cloud KMS KeyService
+ * @Description: A KeyService that wraps/unwraps DEKs using a Google Cloud
+ * @Description: KMS crypto key, so the long-lived key material never leaves
+ * @Description: Cloud KMS.
+ * @Author: thesyscoder/kylon (github.com/thesyscoder)
+ */
+
+package secrets
+
+import (
+	"context"
+	"net/http"
+
+	kms "cloud.google.com/go/kms/apiv1"
+	kmspb "cloud.google.com/go/kms/apiv1/kmspb"
+	"github.com/thesyscoder/kylon/internal/infrastructure/config"
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+)
+
+// gcpKMSKeyService wraps DEKs via the Cloud KMS Encrypt/Decrypt APIs.
+type gcpKMSKeyService struct {
+	client  *kms.KeyManagementClient
+	keyName string
+}
+
+// newGCPKMSKeyService builds a gcpKMSKeyService using application-default
+// credentials, scoped to the configured crypto key resource name.
+func newGCPKMSKeyService(ctx context.Context, cfg config.GCPKMSKeyConfig) (*gcpKMSKeyService, error) {
+	if cfg.KeyName == "" {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeConfigValidationFailed,
+			"secrets.gcpKms.keyName is required when secrets.backend is 'gcp-kms'.",
+			nil,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	client, err := kms.NewKeyManagementClient(ctx)
+	if err != nil {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeSecretLoadFailed,
+			"Failed to construct Cloud KMS client.",
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	return &gcpKMSKeyService{client: client, keyName: cfg.KeyName}, nil
+}
+
+// Encrypt seals plaintext under a fresh DEK, then asks Cloud KMS to encrypt
+// the DEK itself under the configured crypto key.
+func (s *gcpKMSKeyService) Encrypt(ctx context.Context, plaintext []byte) ([]byte, string, error) {
+	dek, err := generateDEK()
+	if err != nil {
+		return nil, "", customerrors.NewCustomError(customerrors.ErrCodeEncryptionFailed, "Failed to generate DEK.", err, http.StatusInternalServerError, nil)
+	}
+
+	resp, err := s.client.Encrypt(ctx, &kmspb.EncryptRequest{
+		Name:      s.keyName,
+		Plaintext: dek,
+	})
+	if err != nil {
+		return nil, "", customerrors.NewCustomError(customerrors.ErrCodeEncryptionFailed, "Cloud KMS failed to encrypt the DEK.", err, http.StatusInternalServerError, nil)
+	}
+
+	ciphertext, err := sealWithDEK(plaintext, dek, resp.Ciphertext)
+	if err != nil {
+		return nil, "", customerrors.NewCustomError(customerrors.ErrCodeEncryptionFailed, "Failed to seal plaintext with DEK.", err, http.StatusInternalServerError, nil)
+	}
+
+	return ciphertext, resp.Name, nil
+}
+
+// Decrypt asks Cloud KMS to decrypt the wrapped DEK, then opens the sealed plaintext.
+func (s *gcpKMSKeyService) Decrypt(ctx context.Context, ciphertext []byte, _ string) ([]byte, error) {
+	wrappedDEK, err := wrappedDEKFromEnvelope(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Decrypt(ctx, &kmspb.DecryptRequest{
+		Name:       s.keyName,
+		Ciphertext: wrappedDEK,
+	})
+	if err != nil {
+		return nil, customerrors.NewCustomError(customerrors.ErrCodeDecryptionFailed, "Cloud KMS failed to decrypt the DEK.", err, http.StatusInternalServerError, nil)
+	}
+
+	return openEnvelope(ciphertext, resp.Plaintext)
+}
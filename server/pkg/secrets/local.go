@@ -0,0 +1,122 @@
+/**
+ * @File: local.go
+ * @Title: Local Master-Key KeyService
+ * @Description: A KeyService backed by a single master key read from an
+ * @Description: environment variable or file, used to wrap/unwrap DEKs with
+ * @Description: AES-GCM. Intended for local development and single-node
+ * @Description: deployments; production deployments should prefer one of the
+ * @Description: cloud KMS backends.
+ * @Author: thesyscoder (github.com/thesyscoder)
+ */
+
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/thesyscoder/kylon/internal/infrastructure/config"
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+)
+
+// localDEKID is the fixed dekID reported by localKeyService; there is only
+// ever one master key, so there is nothing to disambiguate between.
+const localDEKID = "local-master-key-v1"
+
+// localKeyService wraps DEKs with a single master key using AES-GCM.
+type localKeyService struct {
+	masterKey []byte
+}
+
+// newLocalKeyService loads the master key from cfg.MasterKeyEnv (base64) or,
+// failing that, cfg.MasterKeyFile, and validates it is usable as an AES-256 key.
+func newLocalKeyService(cfg config.LocalKeyConfig) (*localKeyService, error) {
+	var encoded string
+
+	if cfg.MasterKeyEnv != "" {
+		encoded = os.Getenv(cfg.MasterKeyEnv)
+	}
+	if encoded == "" && cfg.MasterKeyFile != "" {
+		data, err := os.ReadFile(cfg.MasterKeyFile)
+		if err != nil {
+			return nil, customerrors.NewCustomError(
+				customerrors.ErrCodeSecretLoadFailed,
+				"Failed to read local master key file.",
+				err,
+				http.StatusInternalServerError,
+				nil,
+			)
+		}
+		encoded = strings.TrimSpace(string(data))
+	}
+	if encoded == "" {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeSecretLoadFailed,
+			"Local secrets backend selected but no master key was found in the configured env var or file.",
+			nil,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	masterKey, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeSecretLoadFailed,
+			"Local master key is not valid base64.",
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+	if len(masterKey) != dekSize {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeSecretLoadFailed,
+			"Local master key must decode to 32 bytes for AES-256.",
+			nil,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	return &localKeyService{masterKey: masterKey}, nil
+}
+
+// Encrypt envelope-encrypts plaintext: a fresh DEK seals the plaintext, and
+// the master key wraps the DEK.
+func (s *localKeyService) Encrypt(_ context.Context, plaintext []byte) ([]byte, string, error) {
+	dek, err := generateDEK()
+	if err != nil {
+		return nil, "", customerrors.NewCustomError(customerrors.ErrCodeEncryptionFailed, "Failed to generate DEK.", err, http.StatusInternalServerError, nil)
+	}
+
+	wrappedDEK, err := sealWithDEK(dek, s.masterKey, nil)
+	if err != nil {
+		return nil, "", customerrors.NewCustomError(customerrors.ErrCodeEncryptionFailed, "Failed to wrap DEK with local master key.", err, http.StatusInternalServerError, nil)
+	}
+
+	ciphertext, err := sealWithDEK(plaintext, dek, wrappedDEK)
+	if err != nil {
+		return nil, "", customerrors.NewCustomError(customerrors.ErrCodeEncryptionFailed, "Failed to seal plaintext with DEK.", err, http.StatusInternalServerError, nil)
+	}
+
+	return ciphertext, localDEKID, nil
+}
+
+// Decrypt unwraps the DEK with the master key and opens the sealed plaintext.
+func (s *localKeyService) Decrypt(_ context.Context, ciphertext []byte, _ string) ([]byte, error) {
+	wrappedDEK, err := wrappedDEKFromEnvelope(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	dek, err := openEnvelope(wrappedDEK, s.masterKey)
+	if err != nil {
+		return nil, customerrors.NewCustomError(customerrors.ErrCodeDecryptionFailed, "Failed to unwrap DEK with local master key.", err, http.StatusInternalServerError, nil)
+	}
+
+	return openEnvelope(ciphertext, dek)
+}
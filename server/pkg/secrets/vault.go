@@ -0,0 +1,106 @@
+/**
+ * @File: vault.go
+ * @Title: HashiCorp Vault Transit KeyService
+ * @Description: A KeyService that wraps/unwraps DEKs using Vault's Transit
+ * @Description: secrets engine, so the long-lived key material never leaves
+ * @Description: Vault.
+ * @Author: thesyscoder/kylon (github.com/thesyscoder)
+ */
+
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/thesyscoder/kylon/internal/infrastructure/config"
+	customerrors "github.com/thesyscoder/kylon/pkg/customErrors"
+)
+
+// vaultKeyService wraps DEKs via Vault's Transit engine encrypt/decrypt endpoints.
+type vaultKeyService struct {
+	client  *vaultapi.Client
+	keyName string
+}
+
+// newVaultKeyService builds a vaultKeyService pointed at the configured Vault
+// address, authenticated with the configured token.
+func newVaultKeyService(cfg config.VaultTransitConfig) (*vaultKeyService, error) {
+	if cfg.Address == "" || cfg.KeyName == "" {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeConfigValidationFailed,
+			"secrets.vault.address and secrets.vault.keyName are required when secrets.backend is 'vault'.",
+			nil,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+
+	vaultCfg := vaultapi.DefaultConfig()
+	vaultCfg.Address = cfg.Address
+
+	client, err := vaultapi.NewClient(vaultCfg)
+	if err != nil {
+		return nil, customerrors.NewCustomError(
+			customerrors.ErrCodeSecretLoadFailed,
+			"Failed to construct Vault client.",
+			err,
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+	client.SetToken(cfg.Token)
+
+	return &vaultKeyService{client: client, keyName: cfg.KeyName}, nil
+}
+
+// Encrypt seals plaintext under a fresh DEK, then asks Vault's Transit engine
+// to encrypt the DEK itself under the configured named key.
+func (s *vaultKeyService) Encrypt(ctx context.Context, plaintext []byte) ([]byte, string, error) {
+	dek, err := generateDEK()
+	if err != nil {
+		return nil, "", customerrors.NewCustomError(customerrors.ErrCodeEncryptionFailed, "Failed to generate DEK.", err, http.StatusInternalServerError, nil)
+	}
+
+	secret, err := s.client.Logical().WriteWithContext(ctx, fmt.Sprintf("transit/encrypt/%s", s.keyName), map[string]interface{}{
+		"plaintext": base64.StdEncoding.EncodeToString(dek),
+	})
+	if err != nil || secret == nil {
+		return nil, "", customerrors.NewCustomError(customerrors.ErrCodeEncryptionFailed, "Vault Transit failed to encrypt the DEK.", err, http.StatusInternalServerError, nil)
+	}
+	vaultCiphertext, _ := secret.Data["ciphertext"].(string)
+
+	ciphertext, err := sealWithDEK(plaintext, dek, []byte(vaultCiphertext))
+	if err != nil {
+		return nil, "", customerrors.NewCustomError(customerrors.ErrCodeEncryptionFailed, "Failed to seal plaintext with DEK.", err, http.StatusInternalServerError, nil)
+	}
+
+	return ciphertext, s.keyName, nil
+}
+
+// Decrypt asks Vault's Transit engine to decrypt the wrapped DEK, then opens
+// the sealed plaintext.
+func (s *vaultKeyService) Decrypt(ctx context.Context, ciphertext []byte, _ string) ([]byte, error) {
+	wrappedDEK, err := wrappedDEKFromEnvelope(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := s.client.Logical().WriteWithContext(ctx, fmt.Sprintf("transit/decrypt/%s", s.keyName), map[string]interface{}{
+		"ciphertext": string(wrappedDEK),
+	})
+	if err != nil || secret == nil {
+		return nil, customerrors.NewCustomError(customerrors.ErrCodeDecryptionFailed, "Vault Transit failed to decrypt the DEK.", err, http.StatusInternalServerError, nil)
+	}
+	encodedDEK, _ := secret.Data["plaintext"].(string)
+
+	dek, err := base64.StdEncoding.DecodeString(encodedDEK)
+	if err != nil {
+		return nil, customerrors.NewCustomError(customerrors.ErrCodeDecryptionFailed, "Vault returned a non-base64 DEK.", err, http.StatusInternalServerError, nil)
+	}
+
+	return openEnvelope(ciphertext, dek)
+}